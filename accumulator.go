@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrOverlap is returned by Accumulator if a chunk overlaps with a range
+// that has already been added.
+var ErrOverlap = errors.New("the chunk overlaps with an already added range")
+
+// Range is a range of bytes of an object.
+type Range struct {
+	Offset int64
+	Len    int64
+}
+
+// Accumulator calculates the CRC of an object of a known size from chunks
+// that arrive in any order (e.g. the byte ranges of a multipart download).
+// It keeps only the CRCs of the contiguous ranges received so far and merges
+// adjacent ranges with Combine: the chunks themselves aren't buffered.
+// An Accumulator is safe for concurrent use by multiple goroutines.
+type Accumulator[T UInt] struct {
+	a    Algo[T]
+	size int64
+
+	mu     sync.Mutex
+	ranges []accRange[T] // sorted, disjoint and non-adjacent
+}
+
+type accRange[T UInt] struct {
+	Range
+	crc T // the CRC of the bytes of the range
+}
+
+// NewAccumulator creates an Accumulator for an object of size bytes.
+func NewAccumulator[T UInt](a Algo[T], size int64) *Accumulator[T] {
+	if size < 0 {
+		panic("size is negative")
+	}
+	return &Accumulator[T]{a: a, size: size}
+}
+
+// Add adds the chunk of the object at the given offset. The CRC of the chunk
+// is calculated before acquiring the lock of the Accumulator so several
+// goroutines can add chunks in parallel. It returns ErrOverlap if the chunk
+// overlaps with a previously added one and an error if the chunk is outside
+// of the object.
+func (acc *Accumulator[T]) Add(offset int64, chunk []byte) error {
+	if err := acc.check(offset, int64(len(chunk))); err != nil {
+		return err
+	}
+	return acc.AddCRC(offset, int64(len(chunk)), acc.a.Calc(chunk))
+}
+
+// AddCRC adds a range of the object by its CRC calculated with the
+// algorithm of the Accumulator. It's useful when the CRCs of the chunks are
+// already known (e.g. because they were checked upon arrival).
+func (acc *Accumulator[T]) AddCRC(offset, length int64, crc T) error {
+	if err := acc.check(offset, length); err != nil {
+		return err
+	}
+	if length == 0 {
+		return nil
+	}
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	r := accRange[T]{Range{offset, length}, crc}
+	// i is the index of the first range after the new one
+	i := sort.Search(len(acc.ranges), func(i int) bool { return acc.ranges[i].Offset >= offset })
+	if i > 0 && acc.ranges[i-1].end() > offset || i < len(acc.ranges) && r.end() > acc.ranges[i].Offset {
+		return ErrOverlap
+	}
+	if i < len(acc.ranges) && r.end() == acc.ranges[i].Offset {
+		next := acc.ranges[i]
+		r.crc = acc.a.Combine(r.crc, next.crc, next.Len)
+		r.Len += next.Len
+		acc.ranges = append(acc.ranges[:i], acc.ranges[i+1:]...)
+	}
+	if i > 0 && acc.ranges[i-1].end() == offset {
+		prev := &acc.ranges[i-1]
+		prev.crc = acc.a.Combine(prev.crc, r.crc, r.Len)
+		prev.Len += r.Len
+		return nil
+	}
+	acc.ranges = append(acc.ranges, accRange[T]{})
+	copy(acc.ranges[i+1:], acc.ranges[i:])
+	acc.ranges[i] = r
+	return nil
+}
+
+func (acc *Accumulator[T]) check(offset, length int64) error {
+	if offset < 0 || length < 0 || offset > acc.size || length > acc.size-offset {
+		return errors.New("the chunk is outside of the object")
+	}
+	return nil
+}
+
+func (r *accRange[T]) end() int64 {
+	return r.Offset + r.Len
+}
+
+// Gaps returns the ranges of the object that haven't been added yet in
+// ascending order.
+func (acc *Accumulator[T]) Gaps() []Range {
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	var gaps []Range
+	var off int64
+	for _, r := range acc.ranges {
+		if r.Offset > off {
+			gaps = append(gaps, Range{off, r.Offset - off})
+		}
+		off = r.end()
+	}
+	if off < acc.size {
+		gaps = append(gaps, Range{off, acc.size - off})
+	}
+	return gaps
+}
+
+// Covered returns the number of bytes added so far.
+func (acc *Accumulator[T]) Covered() int64 {
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	var n int64
+	for _, r := range acc.ranges {
+		n += r.Len
+	}
+	return n
+}
+
+// Final returns the CRC of the whole object and true if all of its bytes
+// have been added. Otherwise it returns false.
+func (acc *Accumulator[T]) Final() (T, bool) {
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	if acc.size == 0 {
+		return acc.a.Calc(nil), true
+	}
+	if len(acc.ranges) != 1 || acc.ranges[0].Len != acc.size {
+		return 0, false
+	}
+	return acc.ranges[0].crc, true
+}
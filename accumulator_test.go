@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestAccumulator(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	data := make([]byte, 10000)
+	rnd.Read(data)
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			// random chunk boundaries added in random order
+			cuts := []int{0, len(data)}
+			for i := 0; i < 30; i++ {
+				cuts = append(cuts, rnd.Intn(len(data)+1))
+			}
+			sort.Ints(cuts)
+			acc := crc.NewAccumulator(p.preset, int64(len(data)))
+			order := rnd.Perm(len(cuts) - 1)
+			for k, i := range order {
+				if _, ok := acc.Final(); ok {
+					t.Fatalf("Final succeeded after %d of %d chunks", k, len(order))
+				}
+				if err := acc.Add(int64(cuts[i]), data[cuts[i]:cuts[i+1]]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, ok := acc.Final()
+			if want := p.preset.Calc(data); !ok || got != want {
+				t.Errorf("crc=%#x ok=%t, want %#x", got, ok, want)
+			}
+			if gaps := acc.Gaps(); len(gaps) != 0 {
+				t.Errorf("gaps=%v", gaps)
+			}
+		})
+	}
+}
+
+func TestAccumulatorGaps(t *testing.T) {
+	acc := crc.NewAccumulator(crc.CRC32.Algo(), 100)
+	data := make([]byte, 100)
+	for _, r := range []crc.Range{{10, 10}, {50, 5}, {20, 5}, {99, 1}} {
+		if err := acc.Add(r.Offset, data[r.Offset:r.Offset+r.Len]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []crc.Range{{0, 10}, {25, 25}, {55, 44}}
+	if gaps := acc.Gaps(); !reflect.DeepEqual(gaps, want) {
+		t.Errorf("gaps=%v, want %v", gaps, want)
+	}
+	if n := acc.Covered(); n != 21 {
+		t.Errorf("covered=%d, want 21", n)
+	}
+	for _, r := range []crc.Range{{5, 6}, {19, 1}, {24, 2}, {0, 100}, {52, 1}} {
+		if err := acc.Add(r.Offset, data[r.Offset:r.Offset+r.Len]); !errors.Is(err, crc.ErrOverlap) {
+			t.Errorf("%v: err=%v, want %v", r, err, crc.ErrOverlap)
+		}
+	}
+	if err := acc.Add(95, data[:10]); err == nil || errors.Is(err, crc.ErrOverlap) {
+		t.Errorf("out of range: err=%v", err)
+	}
+	if err := acc.Add(-1, data[:1]); err == nil {
+		t.Error("negative offset: no error")
+	}
+}
+
+func TestAccumulatorEmpty(t *testing.T) {
+	acc := crc.NewAccumulator(crc.CRC32.Algo(), 0)
+	if got, ok := acc.Final(); !ok || got != crc.CRC32.Calc(nil) {
+		t.Errorf("crc=%#x ok=%t", got, ok)
+	}
+}
+
+func TestAccumulatorConcurrent(t *testing.T) {
+	data := make([]byte, 1<<16)
+	rand.New(rand.NewSource(42)).Read(data)
+	acc := crc.NewAccumulator(crc.CRC32C.Algo(), int64(len(data)))
+	const chunk = 1000
+	var wg sync.WaitGroup
+	for off := 0; off < len(data); off += chunk {
+		end := off + chunk
+		if end > len(data) {
+			end = len(data)
+		}
+		wg.Add(1)
+		go func(off, end int) {
+			defer wg.Done()
+			if err := acc.Add(int64(off), data[off:end]); err != nil {
+				t.Error(err)
+			}
+		}(off, end)
+	}
+	wg.Wait()
+	if got, ok := acc.Final(); !ok || got != crc.CRC32C.Calc(data) {
+		t.Errorf("crc=%#x ok=%t, want %#x", got, ok, crc.CRC32C.Calc(data))
+	}
+}
@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+// Package analysis computes the error detection properties of CRC polys: the
+// Hamming distance (HD) at a given data word length, the maximum data word
+// length at each HD and the guaranteed burst error detection length. These
+// are the numbers published by Philip Koopman for many polys:
+// https://users.ece.cmu.edu/~koopman/crc/
+//
+// An error pattern goes undetected if it's a multiple of the poly P. The HD is
+// the minimum number of flipped bits (the weight of the error pattern) that
+// can go undetected in a codeword of the given length: the CRC detects all
+// errors of fewer bits.
+//
+// Multiples of P are searched with a meet-in-the-middle algorithm: the weight
+// w error patterns that start at bit 0 are 1 + x^a1 + ... + x^a(w-1) and such
+// a pattern is a multiple of P if the sum of the first half of the x^ai mod P
+// values equals 1 plus the sum of the second half. The search time grows
+// with the codeword length raised to the power of about w/2 but the maximum
+// codeword length of a given HD shrinks quickly as the HD grows so the
+// search is fast for CRC-32 at typical packet lengths.
+package analysis
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// Analyzer computes the error detection properties of a poly.
+// It isn't safe for concurrent use.
+type Analyzer struct {
+	width int
+	poly  uint64 // the poly without the x^width term
+
+	// evenPoly is true if P has an even number of terms: in that case P is
+	// divisible by x+1 and all undetectable error patterns have even weight
+	evenPoly bool
+
+	syndromes []uint64 // syndromes[i] is x^i mod P
+}
+
+// New creates an Analyzer for the poly of a CRC algorithm. The width and
+// poly are in the format of the parameters of crc.NewAlgo and the poly must
+// have a +1 term (like all practical CRC polys).
+func New(width int, poly uint64) (*Analyzer, error) {
+	if width < 1 || width > 64 {
+		return nil, errors.New("width must be between 1 and 64")
+	}
+	if width < 64 && poly>>width != 0 {
+		return nil, errors.New("poly is outside of the range allowed by width")
+	}
+	if poly&1 == 0 {
+		return nil, errors.New("poly must have a +1 term")
+	}
+	return &Analyzer{width: width, poly: poly, evenPoly: bits.OnesCount64(poly)%2 != 0,
+		syndromes: []uint64{1}}, nil
+}
+
+// BurstLength returns the length of the longest burst errors that are
+// always detected: every error pattern whose flipped bits are within a
+// window of at most this many bits is detected regardless of the data
+// length. An error burst of width+1 bits that equals P isn't detected.
+func (a *Analyzer) BurstLength() int {
+	// a burst is x^i*B with B(0)=1 and deg(B)<width so it can't be
+	// divisible by P because gcd(x^i, P)=1 and deg(B)<deg(P)
+	return a.width
+}
+
+// HammingDistance returns the HD of the CRC at the given data word length
+// (in bits): the minimum number of flipped bits that can go undetected in
+// a codeword of dataBitLen+width bits.
+func (a *Analyzer) HammingDistance(dataBitLen int) int {
+	if dataBitLen < 1 {
+		panic("dataBitLen must be positive")
+	}
+	n := dataBitLen + a.width
+	for w := 2; ; w++ {
+		if !(w&1 != 0 && a.evenPoly) && a.minLen(w, n) != 0 {
+			return w
+		}
+	}
+}
+
+// MaxDataLen returns the maximum data word length (in bits) at which the HD
+// of the CRC is at least hd. The search is limited to data words of at most
+// maxBitLen bits: if the HD is still at least hd at that length then it
+// returns maxBitLen and false.
+//
+// The maximum data length of HD=3 and HD=4 is usually very large (e.g. more
+// than 4 billion bits for HD=3 of some CRC-32 polys) so the search is linear
+// with maxBitLen for these. Make sure that maxBitLen is reasonable.
+func (a *Analyzer) MaxDataLen(hd, maxBitLen int) (int, bool) {
+	if hd < 2 {
+		panic("hd must be at least 2")
+	}
+	// upper is the codeword length below which the search continues: every
+	// weight checked so far is absent from codewords of at most upper bits
+	upper := maxBitLen + a.width
+	found := false
+	for w := 2; w < hd; w++ {
+		if w&1 != 0 && a.evenPoly {
+			continue
+		}
+		if n := a.minLen(w, upper); n != 0 {
+			upper, found = n-1, true
+		}
+	}
+	return upper - a.width, found
+}
+
+// minLen returns the minimum codeword length of at most upper bits that has
+// an undetectable weight w error pattern or zero if there is no such length.
+// It assumes that there are no undetectable error patterns of lower weight
+// in codewords of at most upper bits.
+func (a *Analyzer) minLen(w, upper int) int {
+	// the search time grows quickly with the codeword length so short
+	// codewords are searched first
+	for n := a.width + 1; n <= upper; n *= 2 {
+		if n > upper/2 {
+			n = upper
+		}
+		if m := a.search(w, n); m != 0 {
+			return m
+		}
+	}
+	return 0
+}
+
+// search returns the minimum codeword length of at most n bits that has an
+// undetectable weight w error pattern or zero if there is no such length.
+// It assumes that there are no undetectable error patterns of lower weight
+// in codewords of n bits.
+//
+// The pattern starts at bit 0 (the other patterns are its shifted versions)
+// so it has w-1 more bits at positions 1..n-1. Their syndromes are split into
+// a left half stored in a hash table and a right half that is searched in the
+// table. Both halves are sets of distinct positions but a position can
+// appear in both: the common positions cancel out so such a match would mean
+// an undetectable error pattern of lower weight which is impossible.
+func (a *Analyzer) search(w, n int) int {
+	a.grow(n)
+	s := a.syndromes[:n]
+	free := w - 1
+	left := newTable(binomial(n-1, free/2))
+	forSubsets(s, free/2, func(sum uint64, last int) int {
+		left.add(sum, last)
+		return n
+	})
+	best := 0
+	forSubsets(s, free-free/2, func(sum uint64, last int) int {
+		if l, ok := left.get(1 ^ sum); ok {
+			if l > last {
+				last = l
+			}
+			if best == 0 || last+1 < best {
+				best = last + 1
+			}
+		}
+		if best == 0 {
+			return n
+		}
+		return best - 1
+	})
+	return best
+}
+
+func binomial(n, k int) int {
+	r := 1
+	for i := 1; i <= k; i++ {
+		r = r * (n - i + 1) / i
+	}
+	return r
+}
+
+// forSubsets calls f with the sum of the syndromes and the last (largest)
+// position of each k-element subset of positions 1..len(s)-1 in increasing
+// order of their positions. The return value of f is an exclusive upper
+// limit on the positions of the remaining subsets.
+func forSubsets(s []uint64, k int, f func(sum uint64, last int) int) {
+	limit := len(s)
+	var rec func(start, k int, sum uint64, last int)
+	rec = func(start, k int, sum uint64, last int) {
+		if k == 0 {
+			limit = f(sum, last)
+			return
+		}
+		for i := start; i <= limit-k; i++ {
+			rec(i+1, k-1, sum^s[i], i)
+		}
+	}
+	rec(1, k, 0, 0)
+}
+
+// table is an open addressing hash table that maps the sums of syndromes to
+// the minimum last position of the subsets with that sum. It's a lot faster
+// than a map in the inner loop of the search.
+type table struct {
+	keys  []uint64 // zero means an empty slot
+	vals  []int
+	shift uint
+
+	hasZero bool
+	zeroVal int
+}
+
+func newTable(n int) *table {
+	size := 16
+	for size < 2*n {
+		size <<= 1
+	}
+	return &table{keys: make([]uint64, size), vals: make([]int, size),
+		shift: uint(64 - bits.TrailingZeros(uint(size)))}
+}
+
+func (t *table) add(key uint64, val int) {
+	if key == 0 {
+		if !t.hasZero || val < t.zeroVal {
+			t.hasZero, t.zeroVal = true, val
+		}
+		return
+	}
+	mask := len(t.keys) - 1
+	for i := int(key * 0x9e3779b97f4a7c15 >> t.shift); ; i = (i + 1) & mask {
+		switch t.keys[i] {
+		case 0:
+			t.keys[i], t.vals[i] = key, val
+			return
+		case key:
+			if val < t.vals[i] {
+				t.vals[i] = val
+			}
+			return
+		}
+	}
+}
+
+func (t *table) get(key uint64) (int, bool) {
+	if key == 0 {
+		return t.zeroVal, t.hasZero
+	}
+	mask := len(t.keys) - 1
+	for i := int(key * 0x9e3779b97f4a7c15 >> t.shift); ; i = (i + 1) & mask {
+		switch t.keys[i] {
+		case 0:
+			return 0, false
+		case key:
+			return t.vals[i], true
+		}
+	}
+}
+
+// grow makes sure that the syndromes of the first n bit positions are known.
+func (a *Analyzer) grow(n int) {
+	for v := a.syndromes[len(a.syndromes)-1]; len(a.syndromes) < n; {
+		top := v >> (a.width - 1) & 1
+		v <<= 1
+		if a.width < 64 {
+			v &= 1<<a.width - 1
+		}
+		if top != 0 {
+			v ^= a.poly
+		}
+		a.syndromes = append(a.syndromes, v)
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/pasztorpisti/go-crc/analysis"
+)
+
+// The expected values are from Philip Koopman's CRC polynomial zoo.
+func TestMaxDataLen(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		poly       uint64
+		hd         int
+		maxDataLen int
+	}{
+		{"CRC-8/SMBUS", 8, 0x07, 4, 119},
+		{"CRC-16/XMODEM", 16, 0x1021, 3, 32751},
+		{"CRC-16/XMODEM", 16, 0x1021, 4, 32751},
+		{"CRC-16/XMODEM", 16, 0x1021, 5, 0},
+		{"CRC-32/ISO-HDLC", 32, 0x04c11db7, 4, 91607},
+		{"CRC-32/ISO-HDLC", 32, 0x04c11db7, 5, 2974},
+		{"CRC-32/ISO-HDLC", 32, 0x04c11db7, 6, 268},
+		{"CRC-32/ISO-HDLC", 32, 0x04c11db7, 7, 171},
+		{"CRC-32/ISCSI", 32, 0x1edc6f41, 6, 5243},
+		{"CRC-32/ISCSI", 32, 0x1edc6f41, 8, 177},
+	}
+	for _, tc := range tests {
+		a, err := analysis.New(tc.width, tc.poly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n, ok := a.MaxDataLen(tc.hd, 100000); n != tc.maxDataLen || !ok {
+			t.Errorf("%s HD=%d: maxDataLen=%d ok=%t, want %d", tc.name, tc.hd, n, ok, tc.maxDataLen)
+		}
+	}
+}
+
+func TestMaxDataLenLimit(t *testing.T) {
+	a, err := analysis.New(32, 0x04c11db7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := a.MaxDataLen(3, 100000); n != 100000 || ok {
+		t.Errorf("maxDataLen=%d ok=%t, want 100000 false", n, ok)
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	a, err := analysis.New(32, 0x04c11db7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		dataBitLen, hd int
+	}{
+		{171, 7}, {172, 6}, {268, 6}, {269, 5}, {2974, 5}, {2975, 4}, {12000, 4}, {91607, 4}, {91608, 3},
+	}
+	for _, tc := range tests {
+		if hd := a.HammingDistance(tc.dataBitLen); hd != tc.hd {
+			t.Errorf("dataBitLen=%d: HD=%d, want %d", tc.dataBitLen, hd, tc.hd)
+		}
+	}
+
+	// the CRC-3/GSM poly is primitive: 2 bit errors are detected if the
+	// codeword is at most 7 bits
+	a, err = analysis.New(3, 0x3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd := a.HammingDistance(4); hd != 3 {
+		t.Errorf("CRC-3 4 data bits: HD=%d, want 3", hd)
+	}
+	if hd := a.HammingDistance(5); hd != 2 {
+		t.Errorf("CRC-3 5 data bits: HD=%d, want 2", hd)
+	}
+}
+
+func TestBurstLength(t *testing.T) {
+	a, err := analysis.New(16, 0x8005)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := a.BurstLength(); n != 16 {
+		t.Errorf("burst length=%d, want 16", n)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, tc := range []struct {
+		width int
+		poly  uint64
+	}{{0, 1}, {65, 1}, {8, 0x107}, {8, 0x06}} {
+		if _, err := analysis.New(tc.width, tc.poly); err == nil {
+			t.Errorf("width=%d poly=%#x: no error", tc.width, tc.poly)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+// AnyAlgo is an algorithm with its integer type erased: it returns its CRC
+// values as uint64 regardless of the integer type it uses internally. It's
+// useful when the algorithm is chosen at runtime or when algorithms of
+// different integer types have to be handled uniformly.
+type AnyAlgo = Algo[uint64]
+
+// AnyCRC is the CRC instance type of AnyAlgo.
+type AnyCRC = CRC[uint64]
+
+// NewAnyAlgo creates an AnyAlgo that uses the smallest integer type that can
+// hold width bits. The parameters are the same as those of NewAlgo.
+func NewAnyAlgo(width int, poly, init, xorout uint64, refin, refout bool) (AnyAlgo, error) {
+	if err := checkParams(width, poly, init, xorout); err != nil {
+		return nil, err
+	}
+	switch {
+	case width <= 8:
+		return newAnyAlgo(width, uint8(poly), uint8(init), uint8(xorout), refin, refout)
+	case width <= 16:
+		return newAnyAlgo(width, uint16(poly), uint16(init), uint16(xorout), refin, refout)
+	case width <= 32:
+		return newAnyAlgo(width, uint32(poly), uint32(init), uint32(xorout), refin, refout)
+	}
+	return NewAlgo(width, poly, init, xorout, refin, refout)
+}
+
+func newAnyAlgo[T UInt](width int, poly, init, xorout T, refin, refout bool) (AnyAlgo, error) {
+	a, err := NewAlgo(width, poly, init, xorout, refin, refout)
+	if err != nil {
+		return nil, err
+	}
+	return ToAny(a), nil
+}
+
+// ToAny returns an AnyAlgo that performs its calculations with a.
+// Presets can be converted with their Any method without specifying T.
+func ToAny[T UInt](a Algo[T]) AnyAlgo {
+	if a64, ok := any(a).(AnyAlgo); ok {
+		return a64
+	}
+	return &anyAlgo[T]{a}
+}
+
+type anyAlgo[T UInt] struct {
+	a Algo[T]
+}
+
+func (a *anyAlgo[T]) NewCRC() AnyCRC {
+	return &anyCRC[T]{a.a.NewCRC()}
+}
+
+func (a *anyAlgo[T]) Calc(data []byte) uint64 {
+	return uint64(a.a.Calc(data))
+}
+
+func (a *anyAlgo[T]) CalcBits(data []byte, bitLen int) uint64 {
+	return uint64(a.a.CalcBits(data, bitLen))
+}
+
+func (a *anyAlgo[T]) Combine(crc1, crc2 uint64, len2 int64) uint64 {
+	return uint64(a.a.Combine(T(crc1), T(crc2), len2))
+}
+
+func (a *anyAlgo[T]) CombineBits(crc1, crc2 uint64, bitLen2 int64) uint64 {
+	return uint64(a.a.CombineBits(T(crc1), T(crc2), bitLen2))
+}
+
+func (a *anyAlgo[T]) combiner(len2 int64) func(crc1, crc2 uint64) uint64 {
+	f := combinerOf(a.a, len2)
+	return func(crc1, crc2 uint64) uint64 {
+		return uint64(f(T(crc1), T(crc2)))
+	}
+}
+
+func (a *anyAlgo[T]) Patch(crc uint64, length, offset int64, oldData, newData []byte) uint64 {
+	return uint64(a.a.Patch(T(crc), length, offset, oldData, newData))
+}
+
+func (a *anyAlgo[T]) Encode(data []byte, bitLen int) ([]byte, int) {
+	return a.a.Encode(data, bitLen)
+}
+
+func (a *anyAlgo[T]) Verify(codeword []byte, bitLen int) bool {
+	return a.a.Verify(codeword, bitLen)
+}
+
+func (a *anyAlgo[T]) Residue() uint64 {
+	return uint64(a.a.Residue())
+}
+
+func (a *anyAlgo[T]) Params() Params[uint64] {
+	p := a.a.Params()
+	return Params[uint64]{p.Width, uint64(p.Poly), uint64(p.Init), uint64(p.XorOut), p.RefIn, p.RefOut}
+}
+
+type anyCRC[T UInt] struct {
+	CRC[T]
+}
+
+func (c *anyCRC[T]) Final() uint64 {
+	return uint64(c.CRC.Final())
+}
+
+func (c *anyCRC[T]) Residue() uint64 {
+	return uint64(c.CRC.Residue())
+}
+
+func (c *anyCRC[T]) Clone() AnyCRC {
+	return &anyCRC[T]{c.CRC.Clone()}
+}
+
+func (c *anyCRC[T]) Algo() AnyAlgo {
+	return ToAny(c.CRC.Algo())
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestNewAnyAlgo(t *testing.T) {
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			params := p.preset.Params()
+			a, err := crc.NewAnyAlgo(params.Width, params.Poly, params.Init, params.XorOut, params.RefIn, params.RefOut)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c := a.Calc([]byte("123456789")); c != p.check {
+				t.Errorf("check=%x, want %x", c, p.check)
+			}
+			if got := a.Params(); got != params {
+				t.Errorf("params=%+v, want %+v", got, params)
+			}
+		})
+	}
+	if _, err := crc.NewAnyAlgo(65, 0, 0, 0, false, false); err == nil {
+		t.Error("expected an error for width=65")
+	}
+	if _, err := crc.NewAnyAlgo(8, 0x107, 0, 0, false, false); err == nil {
+		t.Error("expected an error for a poly wider than 8 bits")
+	}
+}
+
+func TestToAny(t *testing.T) {
+	a, err := crc.NewAlgo[uint16](16, 0x1021, 0xffff, 0, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a64 := crc.ToAny(a)
+	if c := a64.Calc([]byte("123456789")); c != 0x29b1 {
+		t.Errorf("check=%x, want 29b1", c)
+	}
+	if c := a64.NewCRC().Algo().Calc([]byte("123456789")); c != 0x29b1 {
+		t.Errorf("Algo().Calc: check=%x, want 29b1", c)
+	}
+	if a64 := crc.CRC64XZ.Algo(); crc.ToAny(a64) != a64 {
+		t.Error("ToAny should return Algo[uint64] instances unchanged")
+	}
+}
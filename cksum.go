@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+// CalcCksum returns the checksum printed by the POSIX cksum utility for data.
+// CRC32CKSUM alone doesn't reproduce it because cksum processes the length
+// of the data (in the minimum number of bytes, least significant byte first)
+// after the data itself.
+func CalcCksum(data []byte) uint32 {
+	c := NewCksum()
+	c.Write(data)
+	return c.Sum32()
+}
+
+// Cksum calculates the checksum of the POSIX cksum utility from the data
+// written to it. It implements hash.Hash32 and its Sum method appends the
+// checksum in big-endian byte order.
+type Cksum struct {
+	c CRC[uint32]
+}
+
+// NewCksum returns a new Cksum.
+func NewCksum() *Cksum {
+	return &Cksum{CRC32CKSUM.NewCRC()}
+}
+
+func (c *Cksum) Write(p []byte) (int, error) {
+	c.c.Update(p)
+	return len(p), nil
+}
+
+// Sum32 returns the checksum of the data written so far. It doesn't change
+// the state so more data can be written after calling it.
+func (c *Cksum) Sum32() uint32 {
+	t := c.c.Clone()
+	for n := c.Len(); n != 0; n >>= 8 {
+		t.Update([]byte{byte(n)})
+	}
+	return t.Final()
+}
+
+// Len returns the number of bytes written so far: the second number printed
+// by the cksum utility.
+func (c *Cksum) Len() int64 {
+	return c.c.BitLen() >> 3
+}
+
+func (c *Cksum) Sum(b []byte) []byte {
+	v := c.Sum32()
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (c *Cksum) Reset() {
+	c.c.Reset()
+}
+
+func (c *Cksum) Size() int {
+	return 4
+}
+
+func (c *Cksum) BlockSize() int {
+	return 1
+}
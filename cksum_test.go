@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"bytes"
+	"hash"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+// The expected values are the outputs of GNU coreutils cksum.
+var cksumTests = []struct {
+	data  string
+	cksum uint32
+}{
+	{"", 4294967295},
+	{"a", 1220704766},
+	{"abc", 1219131554},
+	{"123456789", 930766865},
+	{"The quick brown fox jumps over the lazy dog", 2074844392},
+	{string(make([]byte, 300)), 351385237},
+	{string(bytes.Repeat([]byte("x"), 70000)), 4215398528},
+}
+
+func TestCalcCksum(t *testing.T) {
+	for _, tc := range cksumTests {
+		if c := crc.CalcCksum([]byte(tc.data)); c != tc.cksum {
+			t.Errorf("len=%d: cksum=%d, want %d", len(tc.data), c, tc.cksum)
+		}
+	}
+}
+
+func TestCksum(t *testing.T) {
+	var h hash.Hash32 = crc.NewCksum()
+	for _, tc := range cksumTests {
+		h.Reset()
+		data := []byte(tc.data)
+		h.Write(data[:len(data)/2])
+		h.Sum32() // mustn't change the state
+		h.Write(data[len(data)/2:])
+		if c := h.Sum32(); c != tc.cksum {
+			t.Errorf("len=%d: cksum=%d, want %d", len(tc.data), c, tc.cksum)
+		}
+		if n := h.(*crc.Cksum).Len(); n != int64(len(data)) {
+			t.Errorf("len=%d: Len=%d", len(tc.data), n)
+		}
+		want := []byte{byte(tc.cksum >> 24), byte(tc.cksum >> 16), byte(tc.cksum >> 8), byte(tc.cksum)}
+		if sum := h.Sum(nil); !bytes.Equal(sum, want) {
+			t.Errorf("len=%d: sum=%x, want %x", len(tc.data), sum, want)
+		}
+	}
+}
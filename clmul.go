@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+// clmulThreshold is the minimum input length in bytes for which the carry-less
+// multiplication based folding method is used on CPUs that support it.
+const clmulThreshold = 256
+
+// clmulKernel holds the constants used by the folding method.
+//
+// A CRC of width<64 calculated with the scaled polynomial P*x^(64-width) has
+// the same reflected shift register as the original CRC, so the folding
+// method works with the 64-bit reflected form of every poly. The folding
+// constants are x^(d+63) mod P and x^(d-1) mod P where d is the folding
+// distance in bits - one less than x^(d+64) and x^d because the carry-less
+// product of two reflected 64-bit values is one bit off in the 128-bit
+// reflected result.
+type clmulKernel struct {
+	k512 [2]uint64 // folding constants for 4x128 bits
+	k128 [2]uint64 // folding constants for 128 bits
+
+	// Input bytes are transformed with these tables to reflect the bits of
+	// each byte when refin=false. They contain the identity transform when
+	// refin=true.
+	loNibble   [16]byte // low nibble -> transformed byte
+	hiNibble   [16]byte // high nibble -> transformed byte
+	nibbleMask [16]byte
+	refin      bool // the input doesn't have to be transformed in the main loop
+}
+
+// clmulUpd processes data with the folding method. The length of data has to
+// be a multiple of 16 and at least 64.
+func (a *algo[T]) clmulUpd(reg T, data []byte) T {
+	lo, hi := clmulFold(a.clmulKernel(), uint64(reg), data)
+	// The remaining 128 bits are processed with a zero register.
+	reg = 0
+	for _, v := range [2]uint64{lo, hi} {
+		for i := 0; i < 64; i += 8 {
+			reg = a.table[byte(reg)^byte(v>>i)] ^ shr8(reg)
+		}
+	}
+	return reg
+}
+
+func (a *algo[T]) clmulKernel() *clmulKernel {
+	a.clmulOnce.Do(func() {
+		k := new(clmulKernel)
+		p := uint64(a.refPoly)
+		k.k512 = [2]uint64{xPow64(p, 512+63), xPow64(p, 512-1)}
+		k.k128 = [2]uint64{xPow64(p, 128+63), xPow64(p, 128-1)}
+		for i := byte(0); i < 16; i++ {
+			if a.refin {
+				k.loNibble[i], k.hiNibble[i] = i, i<<4
+			} else {
+				k.loNibble[i], k.hiNibble[i] = reflectedBytes[i], reflectedBytes[i<<4]
+			}
+			k.nibbleMask[i] = 0x0f
+		}
+		k.refin = a.refin
+		a.clmul = k
+	})
+	return a.clmul
+}
+
+// xPow64 returns x^n mod P in 64-bit reflected format where refPoly is the
+// reflected form of the scaled polynomial P.
+func xPow64(refPoly uint64, n int) uint64 {
+	v := uint64(1) << 63
+	for ; n > 0; n-- {
+		if v&1 != 0 {
+			v = (v >> 1) ^ refPoly
+		} else {
+			v >>= 1
+		}
+	}
+	return v
+}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+//go:build amd64 && !purego
+
+package crc
+
+// hasCLMUL reports whether the CPU supports the PCLMULQDQ and SSSE3
+// instructions required by clmulFold.
+var hasCLMUL = func() bool {
+	_, _, ecx, _ := cpuid(1, 0)
+	return ecx&(1<<1) != 0 && ecx&(1<<9) != 0
+}()
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// clmulFold XORs reg into the first 8 bytes of data and folds data into
+// a 128-bit value. The length of data has to be a multiple of 16 and at
+// least 64.
+//
+//go:noescape
+func clmulFold(k *clmulKernel, reg uint64, data []byte) (lo, hi uint64)
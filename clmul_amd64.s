@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// XFORM transforms the bytes of x with the nibble tables in X12 and X13.
+// X14 holds the nibble mask. Clobbers X6 and X7.
+#define XFORM(x) \
+	MOVOU  x, X6;   \
+	PAND   X14, X6; \
+	PSRLW  $4, x;   \
+	PAND   X14, x;  \
+	MOVOU  X12, X7; \
+	PSHUFB X6, X7;  \
+	MOVOU  X13, X6; \
+	PSHUFB x, X6;   \
+	POR    X7, X6;  \
+	MOVOU  X6, x
+
+// FOLD multiplies the low and high qwords of x by the low and high qwords
+// of k and stores the XOR of the two products in x. Clobbers tmp.
+#define FOLD(x, k, tmp) \
+	MOVOU     x, tmp;      \
+	PCLMULQDQ $0x00, k, x; \
+	PCLMULQDQ $0x11, k, tmp; \
+	PXOR      tmp, x
+
+// FOLDLOAD folds x and XORs the next 16 (transformed) input bytes into it.
+#define FOLDLOAD(x, k, off) \
+	FOLD(x, k, X4);     \
+	MOVOU off(SI), X5;  \
+	XFORM(X5);          \
+	PXOR  X5, x
+
+// FOLDLOADRAW is the same as FOLDLOAD without transforming the input.
+#define FOLDLOADRAW(x, k, off) \
+	FOLD(x, k, X4);    \
+	MOVOU off(SI), X5; \
+	PXOR  X5, x
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func clmulFold(k *clmulKernel, reg uint64, data []byte) (lo, hi uint64)
+TEXT ·clmulFold(SB), NOSPLIT, $0-56
+	MOVQ k+0(FP), AX
+	MOVQ reg+8(FP), BX
+	MOVQ data_base+16(FP), SI
+	MOVQ data_len+24(FP), CX
+
+	MOVOU 0(AX), X10  // k512
+	MOVOU 16(AX), X11 // k128
+	MOVOU 32(AX), X12 // loNibble
+	MOVOU 48(AX), X13 // hiNibble
+	MOVOU 64(AX), X14 // nibbleMask
+
+	MOVOU 0(SI), X0
+	XFORM(X0)
+	MOVOU 16(SI), X1
+	XFORM(X1)
+	MOVOU 32(SI), X2
+	XFORM(X2)
+	MOVOU 48(SI), X3
+	XFORM(X3)
+	MOVQ  BX, X4
+	PXOR  X4, X0
+	ADDQ  $64, SI
+	SUBQ  $64, CX
+
+	CMPB 80(AX), $0 // refin
+	JNE  loop64raw
+
+loop64:
+	CMPQ CX, $64
+	JB   fold4
+	FOLDLOAD(X0, X10, 0)
+	FOLDLOAD(X1, X10, 16)
+	FOLDLOAD(X2, X10, 32)
+	FOLDLOAD(X3, X10, 48)
+	ADDQ $64, SI
+	SUBQ $64, CX
+	JMP  loop64
+
+loop64raw:
+	CMPQ CX, $64
+	JB   fold4
+	FOLDLOADRAW(X0, X10, 0)
+	FOLDLOADRAW(X1, X10, 16)
+	FOLDLOADRAW(X2, X10, 32)
+	FOLDLOADRAW(X3, X10, 48)
+	ADDQ $64, SI
+	SUBQ $64, CX
+	JMP  loop64raw
+
+fold4:
+	FOLD(X0, X11, X4)
+	PXOR X0, X1
+	FOLD(X1, X11, X4)
+	PXOR X1, X2
+	FOLD(X2, X11, X4)
+	PXOR X2, X3
+
+	CMPB 80(AX), $0 // refin
+	JNE  loop16raw
+
+loop16:
+	CMPQ CX, $16
+	JB   done
+	FOLDLOAD(X3, X11, 0)
+	ADDQ $16, SI
+	SUBQ $16, CX
+	JMP  loop16
+
+loop16raw:
+	CMPQ CX, $16
+	JB   done
+	FOLDLOADRAW(X3, X11, 0)
+	ADDQ $16, SI
+	SUBQ $16, CX
+	JMP  loop16raw
+
+done:
+	MOVQ   X3, lo+40(FP)
+	PSHUFD $0xee, X3, X3
+	MOVQ   X3, hi+48(FP)
+	RET
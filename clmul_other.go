@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+//go:build !amd64 || purego
+
+package crc
+
+const hasCLMUL = false
+
+func clmulFold(k *clmulKernel, reg uint64, data []byte) (lo, hi uint64) {
+	panic("unreachable")
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCLMUL(t *testing.T) {
+	if !hasCLMUL {
+		t.Skip("the CPU doesn't support carry-less multiplication")
+	}
+	rnd := rand.New(rand.NewSource(42))
+	data := make([]byte, 1024)
+	rnd.Read(data)
+	forEachImpl(t, func(t *testing.T, params Params[uint64], bits int) {
+		switch bits {
+		case 8:
+			checkCLMUL(t, newImpl[uint8](t, params), rnd, data)
+		case 16:
+			checkCLMUL(t, newImpl[uint16](t, params), rnd, data)
+		case 32:
+			checkCLMUL(t, newImpl[uint32](t, params), rnd, data)
+		case 64:
+			checkCLMUL(t, newImpl[uint64](t, params), rnd, data)
+		}
+	})
+}
+
+func checkCLMUL[T UInt](t *testing.T, a *algo[T], rnd *rand.Rand, data []byte) {
+	for n := 64; n <= len(data); n += 16 {
+		reg := T(rnd.Uint64()) & (T(1)<<(a.width-1)<<1 - 1)
+		want := reg
+		for _, b := range data[:n] {
+			want = a.bbbUpd(want, b, 8)
+		}
+		if got := a.clmulUpd(reg, data[:n]); got != want {
+			t.Fatalf("n=%d: reg=%x, want %x", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pasztorpisti/go-crc/manifest"
+)
+
+const checkUsage = `Usage: crc check [flags] MANIFEST...
+
+Verifies the files listed in checksum manifests (or stdin if MANIFEST is -)
+and prints OK, FAILED or MISSING for each of them. Relative paths are
+resolved relative to the directory of the manifest. Exits with 1 if a file
+failed the check or is missing.
+
+Manifest formats:
+  sfv    "path CRC" lines with CRC-32 (the default for .sfv files)
+  cksum  "CRC size path" lines in the output format of the cksum utility
+  sum    "CRC  path" lines in the output format of "crc sum" and sha256sum
+         with the algorithm given by -a (the default for other files)
+
+Flags:
+`
+
+func checkMain(args []string) int {
+	fs := flag.NewFlagSet("crc check", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, checkUsage)
+		fs.PrintDefaults()
+	}
+	formatStr := fs.String("format", "", "manifest `format`: sfv, cksum or sum (default: by file extension)")
+	algoStr := fs.String("a", "CRC-32/ISO-HDLC", "`algorithm` of the sum format (see \"crc sum -h\")")
+	quiet := fs.Bool("quiet", false, "don't print OK for successfully verified files")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	algo, err := parseAlgo(*algoStr)
+	if err != nil {
+		errorf("%v", err)
+		return exitUsage
+	}
+	var format *manifest.Format
+	if *formatStr != "" {
+		f, err := manifest.ParseFormat(*formatStr)
+		if err != nil {
+			errorf("%v", err)
+			return exitUsage
+		}
+		format = &f
+	}
+
+	exit := exitOK
+	var failed, missing int
+	for _, name := range fs.Args() {
+		f := manifest.Sum
+		if format != nil {
+			f = *format
+		} else if strings.EqualFold(filepath.Ext(name), ".sfv") {
+			f = manifest.SFV
+		}
+		m, err := readManifest(name, f, algo)
+		if err != nil {
+			errorf("%v", err)
+			exit = exitFailure
+			continue
+		}
+		dir := filepath.Dir(name)
+		if name == "-" {
+			dir = "."
+		}
+		for _, r := range m.Verify(dir) {
+			switch {
+			case r.Status == manifest.OK && *quiet:
+				continue
+			case r.Status == manifest.Failed:
+				failed++
+			case r.Status == manifest.Missing:
+				missing++
+			}
+			if r.Status == manifest.Failed && r.Err != nil {
+				fmt.Printf("%s: %v (%v)\n", r.Path, r.Status, r.Err)
+			} else {
+				fmt.Printf("%s: %v\n", r.Path, r.Status)
+			}
+		}
+	}
+	if failed != 0 {
+		errorf("WARNING: %d file(s) FAILED", failed)
+		exit = exitFailure
+	}
+	if missing != 0 {
+		errorf("WARNING: %d file(s) MISSING", missing)
+		exit = exitFailure
+	}
+	return exit
+}
+
+func readManifest(name string, format manifest.Format, algo namedAlgo) (*manifest.Manifest, error) {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	m, err := manifest.Read(r, format, algo.algo)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return m, nil
+}
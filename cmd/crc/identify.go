@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+const identifyUsage = `Usage: crc identify [flags] FILE...
+
+Finds the presets that calculate the CRC stored at the end or at the
+beginning of each file (or stdin if FILE is -) from the rest of the file.
+With -crc the whole file is the data and the CRC is given on the command
+line. CRCs are tried in both byte orders. Exits with 1 if a file has no
+matching preset.
+
+Flags:
+`
+
+func identifyMain(args []string) int {
+	fs := flag.NewFlagSet("crc identify", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, identifyUsage)
+		fs.PrintDefaults()
+	}
+	crcStr := fs.String("crc", "", "the stored CRC bytes in hex (e.g. 2639f4cb)")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	var stored []byte
+	if *crcStr != "" {
+		var err error
+		if stored, err = hex.DecodeString(trimHexPrefix(*crcStr)); err != nil || len(stored) == 0 {
+			errorf("invalid CRC %q", *crcStr)
+			return exitUsage
+		}
+	}
+
+	exit := exitOK
+	for _, name := range fs.Args() {
+		data, err := readFile(name)
+		if err != nil {
+			errorf("%v", err)
+			exit = exitFailure
+			continue
+		}
+		var matches []crc.Match
+		if stored != nil {
+			matches = crc.Identify(data, stored)
+		} else {
+			matches = crc.IdentifyFrame(data)
+		}
+		if len(matches) == 0 {
+			fmt.Printf("%s: no matching preset\n", name)
+			exit = exitFailure
+		}
+		for _, m := range matches {
+			fmt.Printf("%s: %s\n", name, formatMatch(m, stored == nil))
+		}
+	}
+	return exit
+}
+
+func formatMatch(m crc.Match, showPlacement bool) string {
+	s := m.Preset.Name
+	if m.LittleEndian {
+		s += " little-endian"
+	} else if m.Preset.Params.Width > 8 {
+		s += " big-endian"
+	}
+	if showPlacement {
+		if m.Leading {
+			s += " leading"
+		} else {
+			s += " trailing"
+		}
+	}
+	if len(m.Preset.Aliases) != 0 {
+		s += " (alias: " + strings.Join(m.Preset.Aliases, ", ") + ")"
+	}
+	return s
+}
+
+// readFile reads the file or stdin if name is "-".
+func readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(name)
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+// Command crc is a command line front end for the go-crc packages.
+//
+// Usage:
+//
+//	crc <command> [arguments]
+//
+// Run "crc help" for the list of commands.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Exit codes shared by all commands.
+const (
+	exitOK      = 0
+	exitFailure = 1 // e.g. no match or failed verification
+	exitUsage   = 2
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands = []command{
+	{"check", "verify the files listed in checksum manifests", checkMain},
+	{"identify", "find the presets that match a stored CRC", identifyMain},
+	{"reveng", "find the parameters of an unknown CRC algorithm", revengMain},
+	{"sum", "calculate the CRCs of files", sumMain},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return exitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		usage()
+		return exitOK
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:])
+		}
+	}
+	fmt.Fprintf(os.Stderr, "crc: unknown command %q\n", args[0])
+	usage()
+	return exitUsage
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: crc <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, `Run "crc <command> -h" for the arguments of a command.`)
+}
+
+func errorf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "crc: "+format+"\n", args...)
+}
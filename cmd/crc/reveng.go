@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pasztorpisti/go-crc"
+	"github.com/pasztorpisti/go-crc/reveng"
+)
+
+const revengUsage = `Usage: crc reveng [flags] SAMPLE...
+
+Finds the CRC algorithms that reproduce the CRCs of all samples. A sample is
+DATA:CRC or DATA/BITS:CRC where DATA is the message in hex (or text with
+-text), BITS is the number of message bits in DATA (the default is all bits,
+not supported with -text) and CRC is the CRC of the message in hex.
+
+The poly can be found only if at least two samples have the same length and
+init and xorout can be told apart only if there are samples of different
+lengths. Exits with 1 if no algorithm was found.
+
+Flags:
+`
+
+func revengMain(args []string) int {
+	fs := flag.NewFlagSet("crc reveng", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, revengUsage)
+		fs.PrintDefaults()
+	}
+	width := fs.Int("w", 0, "bit width of the CRC (default: try all widths)")
+	polyStr := fs.String("p", "", "poly in hex (default: search)")
+	var refin, refout optBool
+	fs.Var(&refin, "refin", "`true or false` (default: try both)")
+	fs.Var(&refout, "refout", "`true or false` (default: try both)")
+	text := fs.Bool("text", false, "DATA is text instead of hex")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	opts := &reveng.Options{Width: *width}
+	if *polyStr != "" {
+		p, err := parseHex(*polyStr)
+		if err != nil {
+			errorf("invalid poly: %v", err)
+			return exitUsage
+		}
+		opts.Poly = p
+	}
+	for _, ri := range refin.values() {
+		for _, ro := range refout.values() {
+			opts.Reflections = append(opts.Reflections, reveng.Reflection{RefIn: ri, RefOut: ro})
+		}
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	var samples []reveng.Sample
+	for _, arg := range fs.Args() {
+		s, err := parseSample(arg, *text)
+		if err != nil {
+			errorf("invalid sample %q: %v", arg, err)
+			return exitUsage
+		}
+		samples = append(samples, s)
+	}
+
+	models, err := reveng.Solve(samples, opts)
+	if err != nil {
+		errorf("%v", err)
+		return exitFailure
+	}
+	if len(models) == 0 {
+		errorf("no CRC algorithm found")
+		return exitFailure
+	}
+	for _, m := range models {
+		printModel(m)
+	}
+	return exitOK
+}
+
+// printModel prints the model in the format of the CRC catalogue.
+func printModel(m reveng.Model) {
+	a, err := crc.NewAlgo(m.Width, m.Poly, m.Init, m.XorOut, m.RefIn, m.RefOut)
+	if err != nil {
+		panic(err)
+	}
+	digits := (m.Width + 3) >> 2
+	fmt.Printf("width=%d  poly=0x%0*x  init=0x%0*x  refin=%t  refout=%t  xorout=0x%0*x  check=0x%0*x",
+		m.Width, digits, m.Poly, digits, m.Init, m.RefIn, m.RefOut, digits, m.XorOut,
+		digits, a.Calc([]byte("123456789")))
+	for _, p := range crc.Presets() {
+		if p.Params == m.Params {
+			fmt.Printf("  name=%q", p.Name)
+			break
+		}
+	}
+	if m.Ambiguous {
+		fmt.Print("  (ambiguous)")
+	}
+	fmt.Println()
+}
+
+// parseSample parses DATA[/BITS]:CRC.
+func parseSample(s string, text bool) (reveng.Sample, error) {
+	i := strings.LastIndexByte(s, ':')
+	if i < 0 {
+		return reveng.Sample{}, fmt.Errorf("missing ':'")
+	}
+	var sample reveng.Sample
+	var err error
+	if sample.CRC, err = parseHex(s[i+1:]); err != nil {
+		return reveng.Sample{}, err
+	}
+	s = s[:i]
+	if i = strings.LastIndexByte(s, '/'); i >= 0 && !text {
+		// zero isn't accepted because Sample.BitLen=0 means all bits
+		n, err := strconv.Atoi(s[i+1:])
+		if err != nil || n < 1 {
+			return reveng.Sample{}, fmt.Errorf("invalid bit length %q", s[i+1:])
+		}
+		sample.BitLen = n
+		s = s[:i]
+	}
+	if text {
+		sample.Data = []byte(s)
+	} else if sample.Data, err = hex.DecodeString(s); err != nil {
+		return reveng.Sample{}, err
+	}
+	if sample.BitLen > 8*len(sample.Data) {
+		return reveng.Sample{}, fmt.Errorf("bit length %d is larger than the data", sample.BitLen)
+	}
+	return sample, nil
+}
+
+// parseHex parses a hex number with an optional 0x prefix.
+func parseHex(s string) (uint64, error) {
+	return strconv.ParseUint(trimHexPrefix(s), 16, 64)
+}
+
+// trimHexPrefix removes the optional 0x or 0X prefix of a hex number.
+func trimHexPrefix(s string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+}
+
+// optBool is a boolean flag that can be left unset.
+type optBool struct {
+	set, value bool
+}
+
+func (b *optBool) String() string {
+	if b == nil || !b.set {
+		return ""
+	}
+	return strconv.FormatBool(b.value)
+}
+
+func (b *optBool) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	b.set, b.value = true, v
+	return nil
+}
+
+// values returns the values to try.
+func (b *optBool) values() []bool {
+	if b.set {
+		return []bool{b.value}
+	}
+	return []bool{false, true}
+}
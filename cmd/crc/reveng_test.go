@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseSample(t *testing.T) {
+	tests := []struct {
+		arg    string
+		text   bool
+		data   []byte
+		bitLen int
+		crc    uint64
+	}{
+		{"313233:1a2b", false, []byte("123"), 0, 0x1a2b},
+		{"3132/13:0x1f", false, []byte("12"), 13, 0x1f},
+		{"31:0X1F", false, []byte("1"), 0, 0x1f},
+		{"a:b/c:ff", true, []byte("a:b/c"), 0, 0xff},
+	}
+	for _, tt := range tests {
+		s, err := parseSample(tt.arg, tt.text)
+		if err != nil {
+			t.Errorf("%q: %v", tt.arg, err)
+			continue
+		}
+		if !bytes.Equal(s.Data, tt.data) || s.BitLen != tt.bitLen || s.CRC != tt.crc {
+			t.Errorf("%q: sample=%+v", tt.arg, s)
+		}
+	}
+	for _, arg := range []string{"3132", "313:ff", "3132/17:ff", "3132/0:ff", "3132:xy"} {
+		if _, err := parseSample(arg, false); err == nil {
+			t.Errorf("%q: no error", arg)
+		}
+	}
+}
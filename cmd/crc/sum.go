@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+const sumUsage = `Usage: crc sum [flags] [FILE...]
+
+Prints the CRCs of the files (or stdin if there is no FILE or FILE is -).
+
+An algorithm is either the name of a preset in the CRC catalogue (e.g.
+CRC-32, crc-16/xmodem or CRC16XMODEM) or a list of parameters like
+"width=16,poly=0x1021,init=0xffff,xorout=0,refin=false,refout=false".
+The -a flag can be repeated to calculate several CRCs in one run.
+
+Flags:
+`
+
+func sumMain(args []string) int {
+	fs := flag.NewFlagSet("crc sum", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, sumUsage)
+		fs.PrintDefaults()
+	}
+	var algos algoList
+	fs.Var(&algos, "a", "`algorithm` to use (default CRC-32/ISO-HDLC)")
+	format := fs.String("f", "hex", "output `format`: hex, dec or raw (the CRC bytes in the byte order of the algorithm)")
+	bitLen := fs.Int64("bits", -1, "process only the first `N` bits of the input")
+	sparse := fs.Bool("sparse", false, "skip the holes of sparse files without reading them (Linux only)")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *sparse && *bitLen >= 0 {
+		errorf("-sparse can't be used with -bits")
+		return exitUsage
+	}
+	if len(algos) == 0 {
+		algos.Set("CRC-32/ISO-HDLC")
+	}
+	switch *format {
+	case "hex", "dec", "raw":
+	default:
+		errorf("invalid format %q", *format)
+		return exitUsage
+	}
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	exit := exitOK
+	for _, name := range files {
+		crcs, err := sumFile(name, algos, *bitLen, *sparse)
+		if err != nil {
+			errorf("%v", err)
+			exit = exitFailure
+			continue
+		}
+		for i, c := range crcs {
+			params := algos[i].algo.Params()
+			switch *format {
+			case "raw":
+				out.Write(crcBytes(c, params))
+				continue
+			case "hex":
+				fmt.Fprintf(out, "%0*x", (params.Width+3)>>2, c)
+			case "dec":
+				fmt.Fprintf(out, "%d", c)
+			}
+			if len(algos) > 1 {
+				fmt.Fprintf(out, "  %s", algos[i].name)
+			}
+			fmt.Fprintf(out, "  %s\n", name)
+		}
+	}
+	return exit
+}
+
+// sumFile calculates the CRCs of a file (or stdin if name is "-") with all
+// algorithms. A non-negative bitLen limits the number of processed bits.
+// With sparse=true the holes of sparse files aren't read.
+func sumFile(name string, algos algoList, bitLen int64, sparse bool) ([]uint64, error) {
+	f := os.Stdin
+	if name != "-" {
+		var err error
+		if f, err = os.Open(name); err != nil {
+			return nil, err
+		}
+		defer f.Close()
+	}
+	var r io.Reader = f
+	crcs := make([]crc.AnyCRC, len(algos))
+	for i, a := range algos {
+		crcs[i] = a.algo.NewCRC()
+	}
+	w := crc.NewWriter(crcs...)
+	if sparse {
+		if err := crc.UpdateFile(f, crcs...); err != nil {
+			return nil, err
+		}
+	} else if bitLen < 0 {
+		if _, err := io.Copy(w, r); err != nil {
+			return nil, err
+		}
+	} else {
+		// the whole bytes are streamed and the partial last byte is
+		// processed with UpdateBits
+		if _, err := io.Copy(w, io.LimitReader(r, bitLen>>3)); err != nil {
+			return nil, err
+		}
+		if bitLen&7 != 0 {
+			var last [1]byte
+			if _, err := io.ReadFull(r, last[:]); err != nil {
+				return nil, fmt.Errorf("%s: shorter than %d bits", name, bitLen)
+			}
+			for _, c := range crcs {
+				c.UpdateBits(last[:], int(bitLen&7))
+			}
+		}
+		if bitLen>>3 != crcs[0].BitLen()>>3 {
+			return nil, fmt.Errorf("%s: shorter than %d bits", name, bitLen)
+		}
+	}
+	result := make([]uint64, len(crcs))
+	for i, c := range crcs {
+		result[i] = c.Final()
+	}
+	return result, nil
+}
+
+// crcBytes returns the CRC in the format of the Sum method of crc.NewHash.
+func crcBytes(v uint64, params crc.Params[uint64]) []byte {
+	n := (params.Width + 7) >> 3
+	b := make([]byte, n)
+	for i := range b {
+		if params.RefOut {
+			b[i] = byte(v >> (i << 3))
+		} else {
+			b[n-1-i] = byte(v >> (i << 3))
+		}
+	}
+	return b
+}
+
+type namedAlgo struct {
+	name string
+	algo crc.AnyAlgo
+}
+
+// algoList is a repeatable flag of algorithms.
+type algoList []namedAlgo
+
+func (l *algoList) String() string {
+	if l == nil {
+		return ""
+	}
+	names := make([]string, len(*l))
+	for i, a := range *l {
+		names[i] = a.name
+	}
+	return strings.Join(names, " ")
+}
+
+func (l *algoList) Set(s string) error {
+	a, err := parseAlgo(s)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, a)
+	return nil
+}
+
+// parseAlgo parses a preset name or a list of parameters in the format of
+// the CRC catalogue separated by commas or spaces.
+func parseAlgo(s string) (namedAlgo, error) {
+	if !strings.Contains(s, "=") {
+		p, ok := crc.LookupPreset(s)
+		if !ok {
+			if p, ok := crc.LookupPreset128(s); ok {
+				return namedAlgo{}, fmt.Errorf("%s: widths above 64 bits are not supported", p.Name)
+			}
+			return namedAlgo{}, fmt.Errorf("unknown preset %q", s)
+		}
+		return namedAlgo{p.Name, p.Algo}, nil
+	}
+	var params crc.Params[uint64]
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return namedAlgo{}, fmt.Errorf("invalid parameter %q", field)
+		}
+		var err error
+		switch kv[0] {
+		case "width":
+			params.Width, err = strconv.Atoi(kv[1])
+		case "poly":
+			params.Poly, err = parseHex(kv[1])
+		case "init":
+			params.Init, err = parseHex(kv[1])
+		case "xorout":
+			params.XorOut, err = parseHex(kv[1])
+		case "refin":
+			params.RefIn, err = strconv.ParseBool(kv[1])
+		case "refout":
+			params.RefOut, err = strconv.ParseBool(kv[1])
+		default:
+			err = errors.New("unknown parameter")
+		}
+		if err != nil {
+			return namedAlgo{}, fmt.Errorf("%s: %v", field, err)
+		}
+		seen[kv[0]] = true
+	}
+	if !seen["width"] || !seen["poly"] {
+		return namedAlgo{}, errors.New("width and poly are required")
+	}
+	if params.Width > 64 {
+		return namedAlgo{}, errors.New("widths above 64 bits are not supported")
+	}
+	a, err := crc.NewAnyAlgo(params.Width, params.Poly, params.Init, params.XorOut, params.RefIn, params.RefOut)
+	if err != nil {
+		return namedAlgo{}, err
+	}
+	return namedAlgo{s, a}, nil
+}
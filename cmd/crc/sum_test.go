@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestParseAlgo(t *testing.T) {
+	for _, s := range []string{"CRC-16/XMODEM", "crc16xmodem", "width=16,poly=0x1021", "width=16 poly=1021 init=0 refin=false"} {
+		a, err := parseAlgo(s)
+		if err != nil {
+			t.Errorf("%q: %v", s, err)
+		} else if c := a.algo.Calc([]byte("123456789")); c != 0x31c3 {
+			t.Errorf("%q: check=%#x, want 0x31c3", s, c)
+		}
+	}
+	for _, s := range []string{"CRC-16/NONEXISTENT", "width=16", "width=16,poly=0x1021,foo=1", "width=8,poly=0x100"} {
+		if _, err := parseAlgo(s); err == nil {
+			t.Errorf("%q: no error", s)
+		}
+	}
+	for _, s := range []string{"CRC-82/DARC", "width=82,poly=1"} {
+		if _, err := parseAlgo(s); err == nil || !strings.Contains(err.Error(), "above 64 bits") {
+			t.Errorf("%q: err=%v", s, err)
+		}
+	}
+}
+
+func TestSumFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "data")
+	data := []byte("123456789")
+	if err := os.WriteFile(name, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var algos algoList
+	algos.Set("CRC-32")
+	algos.Set("CRC-5/USB")
+	for _, bitLen := range []int64{-1, 0, 7, 64, 66, 72} {
+		crcs, err := sumFile(name, algos, bitLen, false)
+		if err != nil {
+			t.Fatalf("bitLen=%d: %v", bitLen, err)
+		}
+		n := 8 * len(data)
+		if bitLen >= 0 {
+			n = int(bitLen)
+		}
+		if want := crc.CRC32ISOHDLC.CalcBits(data, n); crcs[0] != uint64(want) {
+			t.Errorf("bitLen=%d: CRC-32=%#x, want %#x", bitLen, crcs[0], want)
+		}
+		if want := crc.CRC5USB.CalcBits(data, n); crcs[1] != uint64(want) {
+			t.Errorf("bitLen=%d: CRC-5/USB=%#x, want %#x", bitLen, crcs[1], want)
+		}
+	}
+	if _, err := sumFile(name, algos, 73, false); err == nil {
+		t.Error("bitLen=73: no error")
+	}
+}
+
+func TestSumFileSparse(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "sparse")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := f.Truncate(1 << 20); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("123456789"), 1<<19); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var algos algoList
+	algos.Set("CRC-32")
+	algos.Set("CRC-5/USB")
+	crcs, err := sumFile(name, algos, -1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := crc.CRC32ISOHDLC.Calc(data); crcs[0] != uint64(want) {
+		t.Errorf("CRC-32=%#x, want %#x", crcs[0], want)
+	}
+	if want := crc.CRC5USB.Calc(data); crcs[1] != uint64(want) {
+		t.Errorf("CRC-5/USB=%#x, want %#x", crcs[1], want)
+	}
+}
+
+func TestCRCBytes(t *testing.T) {
+	if b := crcBytes(0xcbf43926, crc.CRC32ISOHDLC.Any().Params()); !bytes.Equal(b, []byte{0x26, 0x39, 0xf4, 0xcb}) {
+		t.Errorf("CRC-32: %x", b)
+	}
+	if b := crcBytes(0x31c3, crc.CRC16XMODEM.Any().Params()); !bytes.Equal(b, []byte{0x31, 0xc3}) {
+		t.Errorf("CRC-16/XMODEM: %x", b)
+	}
+}
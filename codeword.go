@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+// A codeword is a message followed by its CRC. The bits of the message are
+// packed into bytes in the order the algorithm processes them: starting at
+// the least significant bit of each byte if refin=true and at the most
+// significant bit otherwise. The CRC is appended bit by bit in the same
+// packing starting with its least significant bit if refout=true and with its
+// most significant bit otherwise. This is the order in which serial
+// protocols (e.g. USB and CAN) transmit the CRC and it's the order that
+// produces the magic residue of the CRC catalogue when the whole codeword
+// is processed by the algorithm.
+
+func (a *algo[T]) Encode(data []byte, bitLen int) ([]byte, int) {
+	if bitLen < 0 {
+		bitLen = len(data) << 3
+	} else if bitLen > len(data)<<3 {
+		panic("bitLen is greater than the number of bits in the input data")
+	}
+	v := a.CalcBits(data, bitLen)
+	n := bitLen + a.width
+	cw := make([]byte, (n+7)>>3)
+	copy(cw, data[:(bitLen+7)>>3])
+	if r := bitLen & 7; r != 0 { // zeroing the unused bits of the last byte
+		if a.refin {
+			cw[bitLen>>3] &= 1<<r - 1
+		} else {
+			cw[bitLen>>3] &^= 0xff >> r
+		}
+	}
+	for i := 0; i < a.width; i++ {
+		var bit T
+		if a.refout {
+			bit = v >> i & 1
+		} else {
+			bit = v >> (a.width - 1 - i) & 1
+		}
+		if j := bitLen + i; bit != 0 {
+			if a.refin {
+				cw[j>>3] |= 1 << (j & 7)
+			} else {
+				cw[j>>3] |= 0x80 >> (j & 7)
+			}
+		}
+	}
+	return cw, n
+}
+
+func (a *algo[T]) Verify(codeword []byte, bitLen int) bool {
+	return a.residue(a.tblUpd(a.refInit, codeword, bitLen)) == a.magic
+}
+
+func (a *algo[T]) Residue() T {
+	return a.magic
+}
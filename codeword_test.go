@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			// the codewords of the test table are the names of the presets
+			// followed by their CRCs
+			cw, n := p.preset.Encode([]byte(p.name), -1)
+			if n != p.codeWordBitLen || !bytes.Equal(cw, []byte(p.codeWord)) {
+				t.Errorf("codeword=%q bitLen=%d, want %q bitLen=%d", cw, n, p.codeWord, p.codeWordBitLen)
+			}
+		})
+	}
+}
+
+func TestEncodeBits(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	data := make([]byte, 20)
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				rnd.Read(data)
+				bitLen := rnd.Intn(8*len(data) + 1)
+				cw, n := p.preset.Encode(data, bitLen)
+				if n != bitLen+p.preset.Params().Width || len(cw) != (n+7)/8 {
+					t.Fatalf("bitLen=%d: codeword bitLen=%d len=%d", bitLen, n, len(cw))
+				}
+				c := p.preset.NewCRC()
+				c.UpdateBits(cw, n)
+				if r := c.Residue(); r != p.residue {
+					t.Errorf("bitLen=%d: residue=%x, want %x", bitLen, r, p.residue)
+				}
+				if crc := p.preset.CalcBits(cw, bitLen); crc != p.preset.CalcBits(data, bitLen) {
+					t.Errorf("bitLen=%d: the message part of the codeword has changed", bitLen)
+				}
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			cw := []byte(p.codeWord)
+			if !p.preset.Verify(cw, p.codeWordBitLen) {
+				t.Error("valid codeword rejected")
+			}
+			for i := 0; i < p.codeWordBitLen; i++ {
+				mask := byte(0x80) >> (i & 7)
+				if p.preset.Params().RefIn {
+					mask = 1 << (i & 7)
+				}
+				cw[i>>3] ^= mask
+				if p.preset.Verify(cw, p.codeWordBitLen) {
+					t.Errorf("codeword with flipped bit %d accepted", i)
+				}
+				cw[i>>3] ^= mask
+			}
+		})
+	}
+}
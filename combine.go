@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+// The CRC shift register is a polynomial over GF(2) modulo the (unreflected)
+// poly P. Processing a zero bit multiplies the register by x (mod P) so
+// processing n bits of data B can be expressed as:
+//
+//	reg(A||B) = reg(A)*x^n + reg0(B) (mod P)
+//
+// where reg0(B) is the register after processing B from a zero register.
+// The functions below work with polynomials stored in the same reflected
+// (LSB-first) format as the shift register: the most significant of the
+// width bits holds the coefficient of x^0.
+
+func (a *algo[T]) Combine(crc1, crc2 T, len2 int64) T {
+	if len2 < 0 {
+		panic("len2 is negative")
+	}
+	return a.CombineBits(crc1, crc2, len2<<3)
+}
+
+func (a *algo[T]) CombineBits(crc1, crc2 T, bitLen2 int64) T {
+	if bitLen2 < 0 {
+		panic("bitLen2 is negative")
+	}
+	return a.combineX(crc1, crc2, a.xPow(bitLen2))
+}
+
+// combiner returns a function that does the same as Combine with a fixed
+// len2 but calculates x^n only once.
+func (a *algo[T]) combiner(len2 int64) func(crc1, crc2 T) T {
+	if len2 < 0 {
+		panic("len2 is negative")
+	}
+	xn := a.xPow(len2 << 3)
+	return func(crc1, crc2 T) T {
+		return a.combineX(crc1, crc2, xn)
+	}
+}
+
+// combinerOf returns the combiner of a if it's implemented by this package
+// and a function that calls a.Combine otherwise.
+func combinerOf[T UInt](a Algo[T], len2 int64) func(crc1, crc2 T) T {
+	if c, ok := a.(interface {
+		combiner(len2 int64) func(crc1, crc2 T) T
+	}); ok {
+		return c.combiner(len2)
+	}
+	return func(crc1, crc2 T) T {
+		return a.Combine(crc1, crc2, len2)
+	}
+}
+
+// combineX is CombineBits with x^n mod P precomputed where n is the bit
+// length of B.
+func (a *algo[T]) combineX(crc1, crc2, xn T) T {
+	reg1 := a.residue(crc1 ^ a.xorout)
+	reg2 := a.residue(crc2 ^ a.xorout)
+	// reg2 = refInit*x^n + reg0(B) so refInit has to be cancelled out
+	reg := a.mulMod(reg1^a.refInit, xn) ^ reg2
+	return a.residue(reg) ^ a.xorout
+}
+
+func (a *algo[T]) Patch(crc T, length, offset int64, oldData, newData []byte) T {
+	if len(oldData) != len(newData) {
+		panic("oldData and newData have different lengths")
+	}
+	if offset < 0 || length < 0 || offset > length || int64(len(newData)) > length-offset {
+		panic("the patch is outside of the message")
+	}
+	// The CRC is linear apart from init and xorout that cancel out in the
+	// difference of the old and new messages: the register changes by
+	// reg0(oldData^newData)*x^n where n is the number of bits after the
+	// patch. reg0 of the XOR is the XOR of the reg0 values of the two.
+	delta := a.tblUpd(0, oldData, -1) ^ a.tblUpd(0, newData, -1)
+	tail := length - offset - int64(len(newData))
+	return crc ^ a.residue(a.shift(delta, tail<<3))
+}
+
+// residue converts the shift register to the residue (the final CRC without
+// xorout) and vice versa because the conversion is its own inverse.
+func (a *algo[T]) residue(reg T) T {
+	if a.refout {
+		return reg
+	}
+	return reflect(reg, a.width)
+}
+
+// shift returns the register after processing bitLen zero bits.
+func (a *algo[T]) shift(reg T, bitLen int64) T {
+	return a.mulMod(reg, a.xPow(bitLen))
+}
+
+// xPow returns x^n mod P.
+func (a *algo[T]) xPow(n int64) T {
+	p := a.one()
+	for sq := a.mulX(p); n != 0; n >>= 1 {
+		if n&1 != 0 {
+			p = a.mulMod(p, sq)
+		}
+		sq = a.mulMod(sq, sq)
+	}
+	return p
+}
+
+// mulMod returns x*y mod P.
+func (a *algo[T]) mulMod(x, y T) T {
+	var p T
+	for m := a.one(); m != 0; m >>= 1 {
+		if x&m != 0 {
+			p ^= y
+		}
+		y = a.mulX(y)
+	}
+	return p
+}
+
+// mulX returns v*x mod P.
+func (a *algo[T]) mulX(v T) T {
+	if v&1 != 0 {
+		return (v >> 1) ^ a.refPoly
+	}
+	return v >> 1
+}
+
+// one returns the polynomial 1 (x^0).
+func (a *algo[T]) one() T {
+	return T(1) << (a.width - 1)
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestCombine(t *testing.T) {
+	data := []byte("123456789")
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			for i := 0; i <= len(data); i++ {
+				c := p.preset.Combine(p.preset.Calc(data[:i]), p.preset.Calc(data[i:]), int64(len(data)-i))
+				if c != p.check {
+					t.Errorf("split=%d: check=%x, want %x", i, c, p.check)
+				}
+			}
+		})
+	}
+}
+
+func TestCombineBits(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	a := make([]byte, 100)
+	b := make([]byte, 100)
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				rnd.Read(a)
+				rnd.Read(b)
+				aBits, bBits := rnd.Intn(8*len(a)+1), rnd.Intn(8*len(b)+1)
+				c := p.preset.NewCRC()
+				c.UpdateBits(a, aBits)
+				c.UpdateBits(b, bBits)
+				want := c.Final()
+				got := p.preset.CombineBits(p.preset.CalcBits(a, aBits), p.preset.CalcBits(b, bBits), int64(bBits))
+				if got != want {
+					t.Errorf("aBits=%d bBits=%d: crc=%x, want %x", aBits, bBits, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCombineLong(t *testing.T) {
+	a, err := crc.NewAlgo[uint32](32, 0x04c11db7, 0xffffffff, 0xffffffff, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zeros := make([]byte, 1<<20)
+	prefix := []byte("123456789")
+	want := a.Calc(append(prefix, zeros...))
+	if got := a.Combine(a.Calc(prefix), a.Calc(zeros), int64(len(zeros))); got != want {
+		t.Errorf("crc=%x, want %x", got, want)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	page := make([]byte, 4096)
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			rnd.Read(page)
+			c := p.preset.Calc(page)
+			for i := 0; i < 20; i++ {
+				n := rnd.Intn(20)
+				off := rnd.Intn(len(page) - n + 1)
+				newData := make([]byte, n)
+				rnd.Read(newData)
+				oldData := append([]byte(nil), page[off:off+n]...)
+				copy(page[off:], newData)
+				c = p.preset.Patch(c, int64(len(page)), int64(off), oldData, newData)
+				if want := p.preset.Calc(page); c != want {
+					t.Fatalf("off=%d n=%d: crc=%x, want %x", off, n, c, want)
+				}
+			}
+		})
+	}
+}
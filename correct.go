@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import "errors"
+
+var (
+	// ErrUncorrectable is returned by Corrector if the codeword has more
+	// errors than the Corrector can correct.
+	ErrUncorrectable = errors.New("the codeword has too many errors")
+	// ErrAmbiguous is returned by Corrector if several error patterns
+	// produce the same syndrome so the correction isn't unique.
+	ErrAmbiguous = errors.New("the correction is ambiguous")
+)
+
+// maxSyndromes limits the size of the syndrome table of a Corrector.
+const maxSyndromes = 1 << 24
+
+// Corrector corrects bit errors in codewords of a fixed bit length. The
+// codewords are in the format produced by Algo.Encode and the bit positions
+// are indexes in the bit stream processed by the algorithm: bit i is at byte
+// i/8 and it's the (i%8)th least significant bit of that byte if refin=true
+// and the (i%8)th most significant bit otherwise.
+//
+// Flipping a bit of a codeword changes its residue by a value (the syndrome)
+// that depends only on the position of the bit so the Corrector precomputes
+// the syndromes of all error patterns of at most maxErrors bits. A Corrector
+// can be shared by goroutines after its creation.
+type Corrector[T UInt] struct {
+	a         *algo[T]
+	bitLen    int
+	magicReg  T                  // the shift register of valid codewords
+	syndromes map[T]errorPattern // syndrome -> error pattern
+	positions []int32            // storage of the bit positions of the error patterns
+}
+
+type errorPattern struct {
+	offset    int32 // offset of the bit positions in Corrector.positions
+	weight    int8  // number of flipped bits
+	ambiguous bool
+}
+
+// NewCorrector builds the syndrome table of the error patterns of at most
+// maxErrors flipped bits in codewords of codewordBitLen bits. The table has
+// about C(codewordBitLen, maxErrors) entries: a few thousand bits with
+// maxErrors=2 is fine but larger tables are rejected.
+//
+// Correction is reliable only if maxErrors is less than half of the Hamming
+// distance of the CRC at the given codeword length. Error patterns beyond
+// that are either detected as ambiguous or silently miscorrected.
+func NewCorrector[T UInt](a Algo[T], codewordBitLen, maxErrors int) (*Corrector[T], error) {
+	params := a.Params()
+	if codewordBitLen < params.Width {
+		return nil, errors.New("the codeword is shorter than the CRC")
+	}
+	if maxErrors < 1 || maxErrors > 127 {
+		return nil, errors.New("maxErrors must be between 1 and 127")
+	}
+	// the table holds the patterns of every weight up to maxErrors
+	var total, binom uint64 = 0, 1
+	for k := uint64(1); k <= uint64(maxErrors) && k <= uint64(codewordBitLen); k++ {
+		binom = binom * (uint64(codewordBitLen) - k + 1) / k
+		total += binom
+		if total > maxSyndromes {
+			return nil, errors.New("the syndrome table would be too large")
+		}
+	}
+	a2, err := NewAlgo(params.Width, params.Poly, params.Init, params.XorOut, params.RefIn, params.RefOut)
+	if err != nil {
+		return nil, err
+	}
+	c := &Corrector[T]{a: a2.(*algo[T]), bitLen: codewordBitLen, syndromes: make(map[T]errorPattern)}
+	c.magicReg = c.a.residue(c.a.magic)
+
+	// single[i] is the syndrome of flipping bit i: processing a 1 bit from
+	// a zero register yields refPoly and each following bit multiplies it by x
+	single := make([]T, codewordBitLen)
+	single[codewordBitLen-1] = c.a.refPoly
+	for i := codewordBitLen - 2; i >= 0; i-- {
+		single[i] = c.a.mulX(single[i+1])
+	}
+	pattern := make([]int32, 0, maxErrors)
+	var add func(start int, syndrome T)
+	add = func(start int, syndrome T) {
+		for i := start; i < codewordBitLen; i++ {
+			pattern = append(pattern, int32(i))
+			s := syndrome ^ single[i]
+			c.addPattern(s, pattern)
+			if len(pattern) < maxErrors {
+				add(i+1, s)
+			}
+			pattern = pattern[:len(pattern)-1]
+		}
+	}
+	add(0, 0)
+	return c, nil
+}
+
+func (c *Corrector[T]) addPattern(syndrome T, pattern []int32) {
+	if syndrome == 0 {
+		// the error pattern is a valid codeword: it can't be detected
+		return
+	}
+	if p, ok := c.syndromes[syndrome]; ok {
+		p.ambiguous = true
+		c.syndromes[syndrome] = p
+		return
+	}
+	c.syndromes[syndrome] = errorPattern{int32(len(c.positions)), int8(len(pattern)), false}
+	c.positions = append(c.positions, pattern...)
+}
+
+// Correct returns the positions of the flipped bits of the codeword in
+// ascending order (nil if the codeword is valid) without modifying it.
+// It returns ErrUncorrectable if the codeword has more than maxErrors flipped
+// bits and ErrAmbiguous if the correction isn't unique. Codewords with
+// a different bit length can't be corrected.
+func (c *Corrector[T]) Correct(codeword []byte) ([]int, error) {
+	if len(codeword)<<3 < c.bitLen {
+		return nil, errors.New("the codeword is shorter than the bit length of the Corrector")
+	}
+	syndrome := c.a.tblUpd(c.a.refInit, codeword, c.bitLen) ^ c.magicReg
+	if syndrome == 0 {
+		return nil, nil
+	}
+	p, ok := c.syndromes[syndrome]
+	if !ok {
+		return nil, ErrUncorrectable
+	}
+	if p.ambiguous {
+		return nil, ErrAmbiguous
+	}
+	positions := make([]int, p.weight)
+	for i := range positions {
+		positions[i] = int(c.positions[int(p.offset)+i])
+	}
+	return positions, nil
+}
+
+// Fix corrects the codeword in place and returns the positions of the
+// flipped bits like Correct. The codeword isn't modified if Fix fails.
+func (c *Corrector[T]) Fix(codeword []byte) ([]int, error) {
+	positions, err := c.Correct(codeword)
+	for _, i := range positions {
+		if c.a.refin {
+			codeword[i>>3] ^= 1 << (i & 7)
+		} else {
+			codeword[i>>3] ^= 0x80 >> (i & 7)
+		}
+	}
+	return positions, err
+}
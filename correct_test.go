@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func flipBits(codeword []byte, refin bool, positions ...int) []byte {
+	cw := append([]byte(nil), codeword...)
+	for _, i := range positions {
+		if refin {
+			cw[i>>3] ^= 1 << (i & 7)
+		} else {
+			cw[i>>3] ^= 0x80 >> (i & 7)
+		}
+	}
+	return cw
+}
+
+func TestCorrectorSingleBit(t *testing.T) {
+	// ATM HEC: a 32-bit header followed by CRC-8/I-432-1
+	a := crc.CRC8I4321.Algo()
+	c, err := crc.NewCorrector(a, 40, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cw, n := a.Encode([]byte{0x12, 0x34, 0x56, 0x78}, -1)
+	if positions, err := c.Correct(cw); positions != nil || err != nil {
+		t.Errorf("valid codeword: positions=%v err=%v", positions, err)
+	}
+	for i := 0; i < n; i++ {
+		bad := flipBits(cw, false, i)
+		positions, err := c.Fix(bad)
+		if err != nil || !reflect.DeepEqual(positions, []int{i}) {
+			t.Errorf("bit %d: positions=%v err=%v", i, positions, err)
+		}
+		if !bytes.Equal(bad, cw) {
+			t.Errorf("bit %d: codeword hasn't been fixed", i)
+		}
+	}
+	if _, err := c.Correct(flipBits(cw, false, 3, 17, 30)); err == nil {
+		t.Error("3 flipped bits: no error")
+	}
+}
+
+func TestCorrectorDoubleBit(t *testing.T) {
+	a := crc.CRC32ISOHDLC.Algo()
+	data := make([]byte, 100)
+	rand.New(rand.NewSource(42)).Read(data)
+	cw, n := a.Encode(data, 8*len(data)-3)
+	c, err := crc.NewCorrector(a, n, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rnd := rand.New(rand.NewSource(42))
+	for k := 0; k < 100; k++ {
+		i, j := rnd.Intn(n), rnd.Intn(n)
+		want := []int{i, j}
+		if i > j {
+			want = []int{j, i}
+		} else if i == j {
+			want = []int{i}
+		}
+		bad := flipBits(cw, true, want...)
+		positions, err := c.Fix(bad)
+		if err != nil || !reflect.DeepEqual(positions, want) {
+			t.Errorf("bits %v: positions=%v err=%v", want, positions, err)
+		}
+		if !a.Verify(bad, n) {
+			t.Errorf("bits %v: codeword hasn't been fixed", want)
+		}
+	}
+}
+
+func TestCorrectorAmbiguous(t *testing.T) {
+	// x^3+x+1 is primitive so the syndromes of single bit errors repeat
+	// after 7 bits
+	a := crc.CRC3GSM.Algo()
+	c, err := crc.NewCorrector(a, 16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cw, _ := a.Encode([]byte{0x5a, 0xc3}, 13)
+	if _, err := c.Correct(flipBits(cw, false, 2)); err != crc.ErrAmbiguous {
+		t.Errorf("err=%v, want %v", err, crc.ErrAmbiguous)
+	}
+	bad := flipBits(cw, false, 2)
+	if _, err := c.Fix(bad); err == nil || !bytes.Equal(bad, flipBits(cw, false, 2)) {
+		t.Error("failed Fix modified the codeword")
+	}
+
+	c, err = crc.NewCorrector(a, 7, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cw, _ = a.Encode([]byte{0x50}, 4)
+	for i := 0; i < 7; i++ {
+		if positions, err := c.Correct(flipBits(cw, false, i)); err != nil || !reflect.DeepEqual(positions, []int{i}) {
+			t.Errorf("bit %d: positions=%v err=%v", i, positions, err)
+		}
+	}
+}
+
+func TestNewCorrectorErrors(t *testing.T) {
+	a := crc.CRC32ISOHDLC.Algo()
+	if _, err := crc.NewCorrector(a, 31, 1); err == nil {
+		t.Error("short codeword: no error")
+	}
+	if _, err := crc.NewCorrector(a, 100, 0); err == nil {
+		t.Error("maxErrors=0: no error")
+	}
+	if _, err := crc.NewCorrector(a, 100000, 3); err == nil {
+		t.Error("huge table: no error")
+	}
+	// C(5793, 2) fits in the limit but C(5793, 1) + C(5793, 2) doesn't
+	if _, err := crc.NewCorrector(a, 5793, 2); err == nil {
+		t.Error("huge table of all weights: no error")
+	}
+}
@@ -4,18 +4,27 @@
 // Package crc is an arbitrary-precision CRC calculator that can calculate CRCs
 // of any bit width (between CRC-1 and CRC-64) and can process input of any bit
 // length (the end of the input data doesn't have to be on a byte boundary).
+// CRCs between CRC-65 and CRC-128 can be calculated with NewAlgo128.
 //
 // Whole bytes of the input data are processed with the help of a precalculated
-// 256-element accelerator table. If the end of input isn't byte-aligned then
-// the remaining (7 or fewer) bits are calculated into the CRC by a tableless
-// bit-by-bit method.
+// 256-element accelerator table. Large inputs are processed 8 bytes at a time
+// with the slicing-by-8 method that uses 7 additional tables - these are
+// created only when an algorithm receives its first large input. On amd64 CPUs
+// that support the PCLMULQDQ instruction large inputs are processed with
+// carry-less multiplication based folding instead. If the end of input isn't
+// byte-aligned then the remaining (7 or fewer) bits are calculated
+// into the CRC by a tableless bit-by-bit method.
 //
 // This package provides presets for and has been tested against
 // the 100+ CRC algorithms listed in Greg Cook's CRC catalogue:
 // https://reveng.sourceforge.io/crc-catalogue/all.htm
 package crc
 
-import "errors"
+import (
+	"encoding"
+	"errors"
+	"sync"
+)
 
 // UInt specifies the integer types that can be used for CRC calculations.
 // The bit width of the chosen integer type has to be greater than or equal to
@@ -25,21 +34,87 @@ type UInt interface {
 	uint8 | uint16 | uint32 | uint64
 }
 
-// A CRC instance is a lightweight "throw-away" object that can calculate the
-// CRC of your chunked data with zero or more Update() calls.
+// A CRC instance is a lightweight object that can calculate the CRC of your
+// chunked data with zero or more Update() calls. It can be reused after
+// calling Reset and forked with Clone to calculate the CRCs of several
+// messages that share a common prefix.
+//
+// The state of a CRC instance can be saved with MarshalBinary and restored
+// later (possibly in another process) with the UnmarshalBinary method of
+// a CRC instance that belongs to an algorithm with the same parameters.
+//
+// CRC is implemented only by this package and methods may be added to it.
 type CRC[T UInt] interface {
 	Update(data []byte)
 	UpdateBits(data []byte, bitLen int)
+	// UpdateRepeated processes n repetitions of pattern (e.g. a single 0x00
+	// or 0xff fill byte) in O(log n) time.
+	UpdateRepeated(pattern []byte, n int64)
+	// UpdateZeroBits processes bitLen zero bits in O(log bitLen) time.
+	// The BitLen of the CRC saturates at math.MaxInt64 after these calls.
+	UpdateZeroBits(bitLen int64)
 	Final() T   // Final returns the final CRC value
 	Residue() T // Residue returns the final CRC value without the xorout step
+
+	Reset()        // Reset restores the initial state of the CRC instance
+	Clone() CRC[T] // Clone returns an independent copy of the CRC instance
+	Algo() Algo[T] // Algo returns the algorithm used by the CRC instance
+	BitLen() int64 // BitLen returns the number of input bits processed so far
+
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
 }
 
 // Algo is a parametrized CRC algorithm. It can be shared and reused by goroutines
 // to save on the resources spent on creating the related accelerator table.
+//
+// Algo is implemented only by this package and methods may be added to it.
 type Algo[T UInt] interface {
 	NewCRC() CRC[T]                     // Calculate the CRC of chunked data
 	Calc(data []byte) T                 // Calculate the CRC of a single chunk of data
 	CalcBits(data []byte, bitLen int) T // Calculate the CRC of a single chunk of data
+
+	// Combine calculates CRC(A||B) from crc1=CRC(A), crc2=CRC(B) and the
+	// byte length of B in O(log(len2)) time.
+	Combine(crc1, crc2 T, len2 int64) T
+	// CombineBits is the same as Combine but it receives the length of B in bits.
+	CombineBits(crc1, crc2 T, bitLen2 int64) T
+	// Patch returns the CRC of a message of length bytes after replacing
+	// oldData at offset with newData of the same length. The crc parameter
+	// is the CRC of the message before the change. It runs in
+	// O(len(newData) + log(length-offset)) time.
+	Patch(crc T, length, offset int64, oldData, newData []byte) T
+
+	// Encode returns the codeword built from the first bitLen bits of data
+	// (all bits if bitLen is negative) followed by their CRC and the bit
+	// length of the codeword. The CRC is appended bit by bit starting with its
+	// least significant bit if refout=true and with its most significant bit
+	// otherwise. Bits are packed into bytes starting at the least significant
+	// bit of each byte if refin=true and at the most significant bit
+	// otherwise. The Residue of the codeword is the magic residue of the
+	// algorithm.
+	Encode(data []byte, bitLen int) (codeword []byte, codewordBitLen int)
+	// Verify checks a codeword (a message followed by its CRC in the order
+	// produced by Encode) by comparing its Residue with the magic residue of
+	// the algorithm. A negative bitLen means all bits of the codeword.
+	Verify(codeword []byte, bitLen int) bool
+	// Residue returns the magic residue of the algorithm: the Residue of
+	// every valid codeword (the "residue" parameter in the CRC catalogue).
+	Residue() T
+
+	Params() Params[T] // Params returns the parameters of the algorithm
+}
+
+// Params holds the parameters of a CRC algorithm as they appear in the
+// Rocksoft model used by the CRC catalogue. Poly and Init are always in
+// (unreflected) MSB-first format - exactly as they are passed to NewAlgo.
+type Params[T UInt] struct {
+	Width  int
+	Poly   T
+	Init   T
+	XorOut T
+	RefIn  bool
+	RefOut bool
 }
 
 // NewAlgo creates a parametrized CRC algorithm instance - this involves the
@@ -51,10 +126,13 @@ func NewAlgo[T UInt](width int, poly, init, xorout T, refin, refout bool) (Algo[
 	if err := checkParams(width, poly, init, xorout); err != nil {
 		return nil, err
 	}
-	a := &algo[T]{width, reflect(poly, width), reflect(init, width), xorout, refin, refout, [256]T{}}
+	a := &algo[T]{width: width, refPoly: reflect(poly, width), refInit: reflect(init, width),
+		xorout: xorout, refin: refin, refout: refout}
 	for i := 1; i < 256; i++ {
 		a.table[i] = a.bbbUpd(T(i), 0, 8)
 	}
+	cw, n := a.Encode(nil, 0)
+	a.magic = a.residue(a.tblUpd(a.refInit, cw, n))
 	return a, nil
 }
 
@@ -76,11 +154,22 @@ type algo[T UInt] struct {
 	xorout  T
 	refin   bool
 	refout  bool
+	magic   T // the residue of valid codewords
 	table   [256]T
+
+	slicing8     *[8][256]T // lazily created slicing-by-8 tables
+	slicing8Once sync.Once
+	clmul        *clmulKernel // lazily created folding constants
+	clmulOnce    sync.Once
+}
+
+func (a *algo[T]) Params() Params[T] {
+	return Params[T]{a.width, reflect(a.refPoly, a.width), reflect(a.refInit, a.width),
+		a.xorout, a.refin, a.refout}
 }
 
 func (a *algo[T]) NewCRC() CRC[T] {
-	return &crc[T]{a, a.refInit}
+	return &crc[T]{a, a.refInit, 0}
 }
 
 func (a *algo[T]) Calc(data []byte) T {
@@ -103,11 +192,21 @@ func (a *algo[T]) tblUpd(reg T, data []byte, bitLen int) (newReg T) {
 		n, bitsLeft = bitLen>>3, bitLen&7
 	}
 
-	for _, b := range data[:n] {
+	p := data[:n]
+	if hasCLMUL && len(p) >= clmulThreshold {
+		reg = a.clmulUpd(reg, p[:len(p)&^15])
+		p = p[len(p)&^15:]
+	}
+	if len(p) >= slicing8Threshold {
+		reg = a.slicing8Upd(reg, p[:len(p)&^7])
+		p = p[len(p)&^7:]
+	}
+
+	for _, b := range p {
 		if !a.refin {
 			b = reflectedBytes[b]
 		}
-		reg = a.table[byte(reg)^b] ^ (reg >> 8)
+		reg = a.table[byte(reg)^b] ^ shr8(reg)
 	}
 
 	if bitsLeft > 0 { // 7 or less input data bits remaining
@@ -135,16 +234,22 @@ func (a *algo[T]) bbbUpd(reg T, b byte, bitLen int) (newReg T) {
 }
 
 type crc[T UInt] struct {
-	a   *algo[T]
-	reg T // reflected (LSB-first) CRC shift register
+	a      *algo[T]
+	reg    T     // reflected (LSB-first) CRC shift register
+	bitLen int64 // number of processed input bits
 }
 
 func (c *crc[T]) Update(data []byte) {
 	c.reg = c.a.tblUpd(c.reg, data, -1)
+	c.bitLen += int64(len(data)) << 3
 }
 
 func (c *crc[T]) UpdateBits(data []byte, bitLen int) {
 	c.reg = c.a.tblUpd(c.reg, data, bitLen)
+	if bitLen < 0 {
+		bitLen = len(data) << 3
+	}
+	c.bitLen += int64(bitLen)
 }
 
 func (c *crc[T]) Final() T {
@@ -152,10 +257,24 @@ func (c *crc[T]) Final() T {
 }
 
 func (c *crc[T]) Residue() T {
-	if c.a.refout {
-		return c.reg
-	}
-	return reflect(c.reg, c.a.width)
+	return c.a.residue(c.reg)
+}
+
+func (c *crc[T]) Reset() {
+	c.reg, c.bitLen = c.a.refInit, 0
+}
+
+func (c *crc[T]) Clone() CRC[T] {
+	c2 := *c
+	return &c2
+}
+
+func (c *crc[T]) Algo() Algo[T] {
+	return c.a
+}
+
+func (c *crc[T]) BitLen() int64 {
+	return c.bitLen
 }
 
 func reflect[T UInt](val T, numBits int) T {
@@ -168,6 +287,12 @@ func reflect[T UInt](val T, numBits int) T {
 	return x
 }
 
+// shr8 returns v>>8. The shift is done on uint64 because go vet flags v>>8 as
+// a mistake when T can be uint8.
+func shr8[T UInt](v T) T {
+	return T(uint64(v) >> 8)
+}
+
 var reflectedBytes [256]byte
 
 func init() {
@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// Uint128 is an unsigned 128-bit integer used by the CRC algorithms that are
+// wider than 64 bits.
+type Uint128 struct {
+	Hi, Lo uint64
+}
+
+// String returns the value in hexadecimal format with a 0x prefix.
+func (u Uint128) String() string {
+	if u.Hi == 0 {
+		return fmt.Sprintf("%#x", u.Lo)
+	}
+	return fmt.Sprintf("%#x%016x", u.Hi, u.Lo)
+}
+
+// CRC128 is the counterpart of CRC for CRC algorithms wider than 64 bits.
+type CRC128 interface {
+	Update(data []byte)
+	UpdateBits(data []byte, bitLen int)
+	Final() Uint128   // Final returns the final CRC value
+	Residue() Uint128 // Residue returns the final CRC value without the xorout step
+}
+
+// Algo128 is the counterpart of Algo for CRC algorithms wider than 64 bits.
+type Algo128 interface {
+	NewCRC() CRC128                           // Calculate the CRC of chunked data
+	Calc(data []byte) Uint128                 // Calculate the CRC of a single chunk of data
+	CalcBits(data []byte, bitLen int) Uint128 // Calculate the CRC of a single chunk of data
+}
+
+// NewAlgo128 is the counterpart of NewAlgo for CRC algorithms wider than
+// 64 bits. Width can be between 1...128 (inclusive). It's slower than NewAlgo
+// so it should be used only with algorithms wider than 64 bits.
+func NewAlgo128(width int, poly, init, xorout Uint128, refin, refout bool) (Algo128, error) {
+	if err := checkParams128(width, poly, init, xorout); err != nil {
+		return nil, err
+	}
+	a := &algo128{width: width, refPoly: reflect128(poly, width), refInit: reflect128(init, width),
+		xorout: xorout, refin: refin, refout: refout}
+	for i := 1; i < 256; i++ {
+		a.table[i] = a.bbbUpd(Uint128{0, uint64(i)}, 0, 8)
+	}
+	return a, nil
+}
+
+func checkParams128(width int, poly, init, xorout Uint128) error {
+	if width <= 0 || width > 128 {
+		return errors.New("width must be between 1 and 128")
+	}
+	m := mask128(width)
+	for _, v := range []Uint128{poly, init, xorout} {
+		if (v.Hi&^m.Hi)|(v.Lo&^m.Lo) != 0 {
+			return errors.New("poly, init or xorout is outside of the range allowed by width")
+		}
+	}
+	return nil
+}
+
+type algo128 struct {
+	width   int
+	refPoly Uint128 // reflected poly
+	refInit Uint128 // reflected init
+	xorout  Uint128
+	refin   bool
+	refout  bool
+	table   [256]Uint128
+}
+
+func (a *algo128) NewCRC() CRC128 {
+	return &crc128{a, a.refInit}
+}
+
+func (a *algo128) Calc(data []byte) Uint128 {
+	return a.CalcBits(data, -1)
+}
+
+func (a *algo128) CalcBits(data []byte, bitLen int) Uint128 {
+	c := a.NewCRC()
+	c.UpdateBits(data, bitLen)
+	return c.Final()
+}
+
+func (a *algo128) tblUpd(reg Uint128, data []byte, bitLen int) (newReg Uint128) {
+	var n, bitsLeft int
+	if bitLen < 0 {
+		n, bitsLeft = len(data), 0
+	} else if bitLen > (len(data) << 3) {
+		panic("bitLen is greater than the number of bits in the input data")
+	} else {
+		n, bitsLeft = bitLen>>3, bitLen&7
+	}
+
+	for _, b := range data[:n] {
+		if !a.refin {
+			b = reflectedBytes[b]
+		}
+		t := &a.table[byte(reg.Lo)^b]
+		reg = Uint128{t.Hi ^ (reg.Hi >> 8), t.Lo ^ (reg.Lo>>8 | reg.Hi<<56)}
+	}
+
+	if bitsLeft > 0 { // 7 or less input data bits remaining
+		return a.bbbUpd(reg, data[n], bitsLeft)
+	}
+	return reg
+}
+
+// bbbUpd performs a bit-by-bit (tableless) update.
+func (a *algo128) bbbUpd(reg Uint128, b byte, bitLen int) (newReg Uint128) {
+	if !a.refin {
+		b = reflectedBytes[b]
+	}
+	b &= (1 << bitLen) - 1 // zeroing the unused bits
+	reg.Lo ^= uint64(b)
+
+	for i := 0; i < bitLen; i++ {
+		lsb := reg.Lo & 1
+		reg = Uint128{reg.Hi >> 1, reg.Lo>>1 | reg.Hi<<63}
+		if lsb != 0 {
+			reg = Uint128{reg.Hi ^ a.refPoly.Hi, reg.Lo ^ a.refPoly.Lo}
+		}
+	}
+	return reg
+}
+
+type crc128 struct {
+	a   *algo128
+	reg Uint128 // reflected (LSB-first) CRC shift register
+}
+
+func (c *crc128) Update(data []byte) {
+	c.reg = c.a.tblUpd(c.reg, data, -1)
+}
+
+func (c *crc128) UpdateBits(data []byte, bitLen int) {
+	c.reg = c.a.tblUpd(c.reg, data, bitLen)
+}
+
+func (c *crc128) Final() Uint128 {
+	r := c.Residue()
+	return Uint128{r.Hi ^ c.a.xorout.Hi, r.Lo ^ c.a.xorout.Lo}
+}
+
+func (c *crc128) Residue() Uint128 {
+	if c.a.refout {
+		return c.reg
+	}
+	return reflect128(c.reg, c.a.width)
+}
+
+func reflect128(val Uint128, numBits int) Uint128 {
+	x := Uint128{bits.Reverse64(val.Lo), bits.Reverse64(val.Hi)}
+	if s := 128 - numBits; s >= 64 {
+		return Uint128{0, x.Hi >> (s - 64)}
+	} else if s > 0 {
+		return Uint128{x.Hi >> s, x.Lo>>s | x.Hi<<(64-s)}
+	}
+	return x
+}
+
+func mask128(width int) Uint128 {
+	if width > 64 {
+		return Uint128{(1 << (width - 64)) - 1, ^uint64(0)}
+	}
+	return Uint128{0, (1 << width) - 1}
+}
+
+// Preset128 is the counterpart of Preset for CRC algorithms wider than
+// 64 bits.
+type Preset128 interface {
+	Algo128
+	Algo() Algo128
+}
+
+func mustNewPreset128(width int, poly, init, xorout Uint128, refin, refout bool) Preset128 {
+	if err := checkParams128(width, poly, init, xorout); err != nil {
+		panic(err)
+	}
+	return &preset128{width: width, poly: poly, init: init, xorout: xorout,
+		refin: refin, refout: refout}
+}
+
+type preset128 struct {
+	width    int
+	poly     Uint128
+	init     Uint128
+	xorout   Uint128
+	refin    bool
+	refout   bool
+	algo     Algo128
+	algoOnce sync.Once
+}
+
+func (p *preset128) NewCRC() CRC128 {
+	return p.Algo().NewCRC()
+}
+
+func (p *preset128) Calc(data []byte) Uint128 {
+	return p.Algo().Calc(data)
+}
+
+func (p *preset128) CalcBits(data []byte, bitLen int) Uint128 {
+	return p.Algo().CalcBits(data, bitLen)
+}
+
+func (p *preset128) Algo() Algo128 {
+	p.algoOnce.Do(func() {
+		a, err := NewAlgo128(p.width, p.poly, p.init, p.xorout, p.refin, p.refout)
+		if err != nil {
+			panic("invalid CRC preset")
+		}
+		p.algo = a
+	})
+	return p.algo
+}
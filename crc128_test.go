@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestCRC82DARC(t *testing.T) {
+	want := crc.Uint128{Hi: 0x9ea8, Lo: 0x3f625023801fd612}
+	if c := crc.CRC82DARC.Calc([]byte("123456789")); c != want {
+		t.Errorf("check=%v, want %v", c, want)
+	}
+	c := crc.CRC82DARC.NewCRC()
+	c.UpdateBits([]byte("CRC82DARC\x8a\xd8JU\x93\xcd\x0e\xd4\xb1\x0c\x01"), 154)
+	if r := c.Residue(); r != (crc.Uint128{}) {
+		t.Errorf("residue=%v, want 0", r)
+	}
+}
+
+// The 128-bit implementation has to produce the same results as the
+// generic one for the presets up to 64 bits.
+func TestAlgo128(t *testing.T) {
+	data := make([]byte, 100)
+	rand.New(rand.NewSource(42)).Read(data)
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			params := p.preset.Params()
+			a, err := crc.NewAlgo128(params.Width, crc.Uint128{Lo: params.Poly}, crc.Uint128{Lo: params.Init},
+				crc.Uint128{Lo: params.XorOut}, params.RefIn, params.RefOut)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c := a.Calc([]byte("123456789")); c != (crc.Uint128{Lo: p.check}) {
+				t.Errorf("check=%v, want %#x", c, p.check)
+			}
+			c := a.NewCRC()
+			c.UpdateBits([]byte(p.codeWord), p.codeWordBitLen)
+			if r := c.Residue(); r != (crc.Uint128{Lo: p.residue}) {
+				t.Errorf("residue=%v, want %#x", r, p.residue)
+			}
+			for bitLen := 0; bitLen <= 8*len(data); bitLen += 37 {
+				want := crc.Uint128{Lo: p.preset.CalcBits(data, bitLen)}
+				if c := a.CalcBits(data, bitLen); c != want {
+					t.Errorf("bitLen=%d: crc=%v, want %v", bitLen, c, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewAlgo128Errors(t *testing.T) {
+	if _, err := crc.NewAlgo128(0, crc.Uint128{}, crc.Uint128{}, crc.Uint128{}, false, false); err == nil {
+		t.Error("expected an error for width=0")
+	}
+	if _, err := crc.NewAlgo128(129, crc.Uint128{}, crc.Uint128{}, crc.Uint128{}, false, false); err == nil {
+		t.Error("expected an error for width=129")
+	}
+	if _, err := crc.NewAlgo128(82, crc.Uint128{Hi: 1 << 18}, crc.Uint128{}, crc.Uint128{}, false, false); err == nil {
+		t.Error("expected an error for a poly wider than 82 bits")
+	}
+}
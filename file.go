@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"io"
+	"os"
+)
+
+// CalcFile returns the CRC of the contents of the named file. The holes of
+// sparse files are processed without reading them like in UpdateFile.
+func CalcFile[T UInt](a Algo[T], name string) (T, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	c := a.NewCRC()
+	if err := UpdateFile(f, c); err != nil {
+		return 0, err
+	}
+	return c.Final(), nil
+}
+
+// UpdateFile processes the contents of f from its current offset to the end
+// of the file with all of the crcs. On Linux the holes of sparse files (e.g.
+// thin-provisioned VM disk images) are found with SEEK_DATA and SEEK_HOLE and
+// processed with UpdateZeroBits instead of reading their zeros. The result
+// is the same as that of reading the whole file. Other platforms and files
+// that can't seek (e.g. pipes) are read sequentially.
+func UpdateFile[T UInt](f *os.File, crcs ...CRC[T]) error {
+	w := NewWriter(crcs...)
+	buf := make([]byte, 1<<16)
+	for {
+		hole, data, err := nextRange(f)
+		if err != nil {
+			return err
+		}
+		for _, c := range crcs {
+			c.UpdateZeroBits(hole << 3)
+		}
+		if data == 0 {
+			return nil
+		}
+		if data < 0 {
+			_, err := io.CopyBuffer(w, f, buf)
+			return err
+		}
+		n, err := io.CopyBuffer(w, io.LimitReader(f, data), buf)
+		if err != nil {
+			return err
+		}
+		if n != data {
+			// the file was truncated while reading it
+			return io.ErrUnexpectedEOF
+		}
+	}
+}
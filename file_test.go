@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+// writeSparse creates a file of the given size with random data at the
+// given offsets and holes everywhere else (on file systems that support
+// them). It returns the contents of the file.
+func writeSparse(t *testing.T, name string, size int64, chunks []int64) []byte {
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := f.Truncate(size); err != nil {
+		t.Fatal(err)
+	}
+	content := make([]byte, size)
+	rnd := rand.New(rand.NewSource(42))
+	for _, off := range chunks {
+		chunk := content[off : off+1000]
+		rnd.Read(chunk)
+		if _, err := f.WriteAt(chunk, off); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return content
+}
+
+func TestCalcFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		size   int64
+		chunks []int64
+	}{
+		{"empty", 0, nil},
+		{"hole", 1 << 20, nil},
+		{"data", 1000, []int64{0}},
+		{"leading-hole", 1 << 20, []int64{1<<20 - 1000}},
+		{"trailing-hole", 1 << 20, []int64{0}},
+		{"holes", 4 << 20, []int64{5000, 1 << 20, 3<<20 + 12345}},
+	}
+	for _, tc := range tests {
+		name := filepath.Join(dir, tc.name)
+		content := writeSparse(t, name, tc.size, tc.chunks)
+		for _, p := range []crc.Preset[uint32]{crc.CRC32, crc.CRC32C} {
+			got, err := crc.CalcFile(p.Algo(), name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := p.Calc(content); got != want {
+				t.Errorf("%s: crc=%#x, want %#x", tc.name, got, want)
+			}
+		}
+	}
+}
+
+func TestUpdateFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "data")
+	content := writeSparse(t, name, 3<<20, []int64{100, 2 << 20})
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	// the file is processed from its current offset
+	const off = 50
+	if _, err := f.Seek(off, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	c1, c2 := crc.CRC32.NewCRC(), crc.CRC64ECMA182.Any().NewCRC()
+	c2.Update([]byte("prefix"))
+	if err := crc.UpdateFile(f, c1); err != nil {
+		t.Fatal(err)
+	}
+	if want := crc.CRC32.Calc(content[off:]); c1.Final() != want {
+		t.Errorf("crc=%#x, want %#x", c1.Final(), want)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	c3 := crc.CRC64ECMA182.Any().NewCRC()
+	if err := crc.UpdateFile(f, c2, c3); err != nil {
+		t.Fatal(err)
+	}
+	if want := crc.CRC64ECMA182.Calc(append([]byte("prefix"), content...)); c2.Final() != want {
+		t.Errorf("crc=%#x, want %#x", c2.Final(), want)
+	}
+	if want := crc.CRC64ECMA182.Calc(content); c3.Final() != want {
+		t.Errorf("crc=%#x, want %#x", c3.Final(), want)
+	}
+}
@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package gf2
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"sort"
+)
+
+// Factor is an irreducible factor of a polynomial with its multiplicity.
+type Factor struct {
+	Poly Poly
+	Exp  int
+}
+
+// IsIrreducible returns true if p has no divisors other than 1 and itself.
+// The zero polynomial and 1 aren't irreducible.
+func (p Poly) IsIrreducible() bool {
+	d := p.Deg()
+	if d < 1 {
+		return false
+	}
+	// Rabin's test: p is irreducible iff it has no common factor with
+	// x^(2^i)-x for i<=d/2 because x^(2^i)-x is the product of all
+	// irreducible polynomials whose degree divides i
+	x := Monomial(1)
+	h := x.Mod(p)
+	for i := 1; i <= d/2; i++ {
+		h = h.MulMod(h, p)
+		if !GCD(p, h.Add(x)).Equal(One) {
+			return false
+		}
+	}
+	return true
+}
+
+// Factor returns the irreducible factors of p sorted by degree and then by
+// their coefficients. It panics if p is zero and returns nil if p is 1.
+func (p Poly) Factor() []Factor {
+	if p.IsZero() {
+		panic("factorization of the zero polynomial")
+	}
+	rnd := rand.New(rand.NewSource(1))
+	var factors []Factor
+	for _, sf := range p.squareFree() {
+		for _, dd := range sf.Poly.distinctDegree() {
+			for _, f := range dd.Poly.equalDegree(dd.Exp, rnd) {
+				factors = append(factors, Factor{f, sf.Exp})
+			}
+		}
+	}
+	sort.Slice(factors, func(i, j int) bool {
+		return factors[i].Poly.less(factors[j].Poly)
+	})
+	return factors
+}
+
+func (p Poly) less(q Poly) bool {
+	if len(p.w) != len(q.w) {
+		return len(p.w) < len(q.w)
+	}
+	for i := len(p.w) - 1; i >= 0; i-- {
+		if p.w[i] != q.w[i] {
+			return p.w[i] < q.w[i]
+		}
+	}
+	return false
+}
+
+// squareFree returns square-free polynomials and their multiplicities whose
+// product is p.
+func (p Poly) squareFree() []Factor {
+	var factors []Factor
+	c := p
+	if d := p.Derivative(); !d.IsZero() {
+		c = GCD(p, d)
+		w := p.Div(c)
+		for i := 1; !w.Equal(One); i++ {
+			y := GCD(w, c)
+			if z := w.Div(y); !z.Equal(One) {
+				factors = append(factors, Factor{z, i})
+			}
+			w, c = y, c.Div(y)
+		}
+	}
+	// c is a square (its derivative is zero) because the factors whose
+	// multiplicity is divisible by 2 are left in it
+	if !c.Equal(One) {
+		for _, f := range c.sqrt().squareFree() {
+			factors = append(factors, Factor{f.Poly, 2 * f.Exp})
+		}
+	}
+	return factors
+}
+
+// sqrt returns the square root of p assuming that p is a square: the
+// square of a polynomial has only even powers because (a+b)^2 = a^2+b^2.
+func (p Poly) sqrt() Poly {
+	r := make([]uint64, (len(p.w)+1)/2)
+	for i := 0; i <= p.Deg(); i += 2 {
+		if p.Coeff(i) {
+			r[i>>7] |= 1 << (i >> 1 & 63)
+		}
+	}
+	return Poly{r}.norm()
+}
+
+// distinctDegree splits a square-free polynomial into products of
+// irreducible factors of the same degree. Exp holds that degree.
+func (p Poly) distinctDegree() []Factor {
+	var factors []Factor
+	x := Monomial(1)
+	h := x.Mod(p)
+	for i := 1; p.Deg() >= 2*i; i++ {
+		h = h.MulMod(h, p) // x^(2^i) mod p
+		if g := GCD(p, h.Add(x)); !g.Equal(One) {
+			factors = append(factors, Factor{g, i})
+			p = p.Div(g)
+			h = h.Mod(p)
+		}
+	}
+	if p.Deg() > 0 {
+		factors = append(factors, Factor{p, p.Deg()})
+	}
+	return factors
+}
+
+// equalDegree splits a product of distinct irreducible polynomials of
+// degree d with the Cantor-Zassenhaus method: the trace a+a^2+...+a^(2^(d-1))
+// of a random a is 0 or 1 modulo each factor so its GCD with p is usually a
+// proper divisor.
+func (p Poly) equalDegree(d int, rnd *rand.Rand) []Poly {
+	if p.Deg() == d {
+		return []Poly{p}
+	}
+	for {
+		w := make([]uint64, len(p.w))
+		for i := range w {
+			w[i] = rnd.Uint64()
+		}
+		a := Poly{w}.norm().Mod(p)
+		t := a
+		for i := 1; i < d; i++ {
+			a = a.MulMod(a, p)
+			t = t.Add(a)
+		}
+		if g := GCD(p, t); g.Deg() > 0 && g.Deg() < p.Deg() {
+			return append(g.equalDegree(d, rnd), p.Div(g).equalDegree(d, rnd)...)
+		}
+	}
+}
+
+// Order returns the multiplicative order of x modulo p: the smallest e>0 for
+// which x^e mod p = 1. A CRC with poly p detects all 2-bit errors in
+// codewords of at most e bits. The order exists only if the constant term
+// of p is 1 and it's supported only if the degree of p is between 1 and 64.
+func (p Poly) Order() (uint64, error) {
+	d := p.Deg()
+	if d < 1 || d > 64 {
+		return 0, errors.New("the degree of the poly must be between 1 and 64")
+	}
+	if !p.Coeff(0) {
+		return 0, errors.New("the poly is divisible by x so x has no order")
+	}
+	order := uint64(1)
+	for _, f := range p.Factor() {
+		// ord(f^e) = ord(f)*2^k where 2^k is the smallest power of 2 >= e
+		e := f.Poly.orderIrreducible() << bits.Len(uint(f.Exp-1))
+		order = order / gcd(order, e) * e
+	}
+	return order, nil
+}
+
+// orderIrreducible returns the order of x modulo an irreducible polynomial of
+// degree 1...64 that isn't x. The order divides 2^d-1 because the nonzero
+// elements of GF(2^d) form a multiplicative group of that size.
+func (p Poly) orderIrreducible() uint64 {
+	n := ^uint64(0) >> (64 - p.Deg())
+	order := n
+	for _, q := range primeFactors(n) {
+		for order%q == 0 && XPowMod(order/q, p).Equal(One) {
+			order /= q
+		}
+	}
+	return order
+}
+
+// IsPrimitive returns true if p is irreducible and x generates all nonzero
+// elements modulo p (the order of x is 2^d-1 where d is the degree of p).
+// Maximum-length LFSRs use primitive polys. It's supported only if the degree
+// of p is between 1 and 64.
+func (p Poly) IsPrimitive() (bool, error) {
+	d := p.Deg()
+	if d < 1 || d > 64 {
+		return false, errors.New("the degree of the poly must be between 1 and 64")
+	}
+	if !p.Coeff(0) || !p.IsIrreducible() {
+		return false, nil
+	}
+	return p.orderIrreducible() == ^uint64(0)>>(64-d), nil
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// primeFactors returns the distinct prime factors of n in ascending order.
+func primeFactors(n uint64) []uint64 {
+	var primes []uint64
+	for _, p := range []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37} {
+		if n%p == 0 {
+			primes = append(primes, p)
+			for n%p == 0 {
+				n /= p
+			}
+		}
+	}
+	var split func(n uint64)
+	split = func(n uint64) {
+		if n == 1 {
+			return
+		}
+		if new(big.Int).SetUint64(n).ProbablyPrime(0) {
+			primes = append(primes, n)
+			return
+		}
+		d := pollardRho(n)
+		split(d)
+		split(n / d)
+	}
+	split(n)
+	sort.Slice(primes, func(i, j int) bool { return primes[i] < primes[j] })
+	// split can find the same prime in both halves
+	r := primes[:0]
+	for i, p := range primes {
+		if i == 0 || p != primes[i-1] {
+			r = append(r, p)
+		}
+	}
+	return r
+}
+
+// pollardRho returns a nontrivial divisor of the odd composite n that has no
+// small prime factors.
+func pollardRho(n uint64) uint64 {
+	mulMod := func(a, b uint64) uint64 {
+		hi, lo := bits.Mul64(a, b)
+		return bits.Rem64(hi, lo, n)
+	}
+	for c := uint64(1); ; c++ {
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		f := func(v uint64) uint64 {
+			v = mulMod(v, v) + c
+			if v < c || v >= n { // overflow or out of range
+				v -= n
+			}
+			return v
+		}
+		for d == 1 {
+			x = f(x)
+			y = f(f(y))
+			if x > y {
+				d = gcd(x-y, n)
+			} else {
+				d = gcd(y-x, n)
+			}
+		}
+		if d != n {
+			return d
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package gf2_test
+
+import (
+	"testing"
+
+	"github.com/pasztorpisti/go-crc/gf2"
+)
+
+func TestCRCPolys(t *testing.T) {
+	tests := []struct {
+		name        string
+		width       int
+		poly        uint64
+		irreducible bool
+		primitive   bool
+		order       uint64
+		factors     []string
+	}{
+		{"CRC-8/SMBUS", 8, 0x07, false, false, 127,
+			[]string{"x + 1", "x^7 + x^6 + x^5 + x^4 + x^3 + x^2 + 1"}},
+		{"CRC-16/XMODEM", 16, 0x1021, false, false, 32767,
+			[]string{"x + 1", "x^15 + x^14 + x^13 + x^12 + x^4 + x^3 + x^2 + x + 1"}},
+		{"CRC-16/ARC", 16, 0x8005, false, false, 32767,
+			[]string{"x + 1", "x^15 + x + 1"}},
+		{"CRC-32/ISO-HDLC", 32, 0x04c11db7, true, true, 1<<32 - 1,
+			[]string{"x^32 + x^26 + x^23 + x^22 + x^16 + x^12 + x^11 + x^10 + x^8 + x^7 + x^5 + x^4 + x^2 + x + 1"}},
+		{"CRC-32/ISCSI", 32, 0x1edc6f41, false, false, 1<<31 - 1,
+			[]string{"x + 1", "x^31 + x^30 + x^29 + x^28 + x^26 + x^24 + x^23 + x^21 + x^20 + x^18 + x^13 + x^10 + x^8 + x^5 + x^4 + x^3 + x^2 + x + 1"}},
+		{"GF(2^64)", 64, 0x1b, true, true, 1<<64 - 1,
+			[]string{"x^64 + x^4 + x^3 + x + 1"}},
+	}
+	for _, tc := range tests {
+		p, err := gf2.FromAlgo(tc.width, tc.poly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.IsIrreducible(); got != tc.irreducible {
+			t.Errorf("%s: IsIrreducible=%t, want %t", tc.name, got, tc.irreducible)
+		}
+		if got, err := p.IsPrimitive(); got != tc.primitive || err != nil {
+			t.Errorf("%s: IsPrimitive=%t, %v, want %t", tc.name, got, err, tc.primitive)
+		}
+		if got, err := p.Order(); got != tc.order || err != nil {
+			t.Errorf("%s: Order=%d, %v, want %d", tc.name, got, err, tc.order)
+		}
+		factors := p.Factor()
+		if len(factors) != len(tc.factors) {
+			t.Errorf("%s: Factor=%v, want %v", tc.name, factors, tc.factors)
+			continue
+		}
+		for i, f := range factors {
+			if f.Poly.String() != tc.factors[i] || f.Exp != 1 {
+				t.Errorf("%s: Factor=%v, want %v", tc.name, factors, tc.factors)
+				break
+			}
+		}
+	}
+}
+
+// TestSmallPolys compares the results with brute force for all polys of
+// degree 1...10.
+func TestSmallPolys(t *testing.T) {
+	var irreducible []gf2.Poly
+	for v := uint64(2); v < 1<<11; v++ {
+		p := gf2.FromUint64(v)
+		isIrreducible := true
+		for _, q := range irreducible {
+			if 2*q.Deg() > p.Deg() {
+				break
+			}
+			if p.Mod(q).IsZero() {
+				isIrreducible = false
+				break
+			}
+		}
+		if isIrreducible {
+			irreducible = append(irreducible, p)
+		}
+		if got := p.IsIrreducible(); got != isIrreducible {
+			t.Errorf("%v: IsIrreducible=%t, want %t", p, got, isIrreducible)
+		}
+
+		product := gf2.One
+		for _, f := range p.Factor() {
+			if !f.Poly.IsIrreducible() {
+				t.Errorf("%v: factor %v isn't irreducible", p, f.Poly)
+			}
+			for i := 0; i < f.Exp; i++ {
+				product = product.Mul(f.Poly)
+			}
+		}
+		if !product.Equal(p) {
+			t.Errorf("%v: the product of the factors is %v", p, product)
+		}
+
+		order, err := p.Order()
+		if !p.Coeff(0) {
+			if err == nil {
+				t.Errorf("%v: Order succeeded", p)
+			}
+			continue
+		}
+		var want uint64
+		x := gf2.Monomial(1).Mod(p)
+		for e, r := uint64(1), x; ; e++ {
+			if r.Equal(gf2.One.Mod(p)) {
+				want = e
+				break
+			}
+			r = r.MulMod(x, p)
+		}
+		if order != want || err != nil {
+			t.Errorf("%v: Order=%d, %v, want %d", p, order, err, want)
+		}
+		primitive, _ := p.IsPrimitive()
+		if want := isIrreducible && want == 1<<p.Deg()-1; primitive != want {
+			t.Errorf("%v: IsPrimitive=%t, want %t", p, primitive, want)
+		}
+	}
+}
+
+func TestFactorMultiplicity(t *testing.T) {
+	a := gf2.FromUint64(0b11)   // x+1
+	b := gf2.FromUint64(0b111)  // x^2+x+1
+	c := gf2.FromUint64(0b1011) // x^3+x+1
+	p := a.Mul(a).Mul(a).Mul(b).Mul(b).Mul(c).Mul(c).Mul(c).Mul(c)
+	factors := p.Factor()
+	want := []gf2.Factor{{a, 3}, {b, 2}, {c, 4}}
+	if len(factors) != len(want) {
+		t.Fatalf("Factor=%v, want %v", factors, want)
+	}
+	for i, f := range factors {
+		if !f.Poly.Equal(want[i].Poly) || f.Exp != want[i].Exp {
+			t.Fatalf("Factor=%v, want %v", factors, want)
+		}
+	}
+	// ord((x+1)^3) = 4 and ord(x^3+x+1) = 7
+	if order, err := a.Mul(a).Mul(a).Mul(c).Order(); order != 28 || err != nil {
+		t.Errorf("Order=%d, %v, want 28", order, err)
+	}
+}
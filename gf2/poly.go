@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+// Package gf2 implements the arithmetic of polynomials over GF(2) - the
+// mathematics behind CRCs. Polynomials of arbitrary degree are supported with
+// the exception of the multiplicative order and the primitivity test that
+// are limited to degree 64.
+//
+// The poly parameter of crc.NewAlgo omits the x^width term of the poly
+// because it's always present: FromAlgo and Poly.Algo convert between that
+// notation and Poly.
+package gf2
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Poly is an immutable polynomial over GF(2). The zero value is the zero
+// polynomial.
+type Poly struct {
+	// bit i of w[i/64] is the coefficient of x^i and the last word is never
+	// zero (the zero polynomial has no words)
+	w []uint64
+}
+
+// New creates a polynomial from 64-bit words: bit i of words[i/64] is the
+// coefficient of x^i.
+func New(words ...uint64) Poly {
+	return Poly{append([]uint64(nil), words...)}.norm()
+}
+
+// FromUint64 returns the polynomial whose coefficients are the bits of v:
+// bit i is the coefficient of x^i.
+func FromUint64(v uint64) Poly {
+	return New(v)
+}
+
+// Monomial returns x^n.
+func Monomial(n int) Poly {
+	if n < 0 {
+		panic("negative exponent")
+	}
+	w := make([]uint64, n>>6+1)
+	w[n>>6] = 1 << (n & 63)
+	return Poly{w}
+}
+
+// One is the polynomial 1.
+var One = FromUint64(1)
+
+// FromAlgo returns x^width + poly: the poly of a CRC algorithm given in the
+// notation of the parameters of crc.NewAlgo.
+func FromAlgo(width int, poly uint64) (Poly, error) {
+	if width < 1 || width > 64 {
+		return Poly{}, errors.New("width must be between 1 and 64")
+	}
+	if width < 64 && poly>>width != 0 {
+		return Poly{}, errors.New("poly is outside of the range allowed by width")
+	}
+	return New(poly).Add(Monomial(width)), nil
+}
+
+// Algo returns the width and poly parameters of crc.NewAlgo for a CRC
+// algorithm that uses p. The degree of p has to be between 1 and 64.
+func (p Poly) Algo() (width int, poly uint64, err error) {
+	d := p.Deg()
+	if d < 1 || d > 64 {
+		return 0, 0, errors.New("the degree of the poly must be between 1 and 64")
+	}
+	poly = p.w[0]
+	if d < 64 {
+		poly &^= 1 << d
+	}
+	return d, poly, nil
+}
+
+func (p Poly) norm() Poly {
+	n := len(p.w)
+	for n > 0 && p.w[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		return Poly{}
+	}
+	return Poly{p.w[:n]}
+}
+
+// Deg returns the degree of p or -1 if p is zero.
+func (p Poly) Deg() int {
+	if len(p.w) == 0 {
+		return -1
+	}
+	return (len(p.w)-1)<<6 + bits.Len64(p.w[len(p.w)-1]) - 1
+}
+
+// IsZero returns true if p is the zero polynomial.
+func (p Poly) IsZero() bool {
+	return len(p.w) == 0
+}
+
+// Coeff returns the coefficient of x^i.
+func (p Poly) Coeff(i int) bool {
+	return i >= 0 && i>>6 < len(p.w) && p.w[i>>6]>>(i&63)&1 != 0
+}
+
+// Uint64 returns the coefficients of x^0...x^63 as the bits of an integer.
+func (p Poly) Uint64() uint64 {
+	if len(p.w) == 0 {
+		return 0
+	}
+	return p.w[0]
+}
+
+// Weight returns the number of nonzero coefficients.
+func (p Poly) Weight() int {
+	n := 0
+	for _, w := range p.w {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Equal returns true if p and q are the same polynomial.
+func (p Poly) Equal(q Poly) bool {
+	if len(p.w) != len(q.w) {
+		return false
+	}
+	for i, w := range p.w {
+		if w != q.w[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Add returns p+q (which is the same as p-q).
+func (p Poly) Add(q Poly) Poly {
+	if len(p.w) < len(q.w) {
+		p, q = q, p
+	}
+	r := append([]uint64(nil), p.w...)
+	for i, w := range q.w {
+		r[i] ^= w
+	}
+	return Poly{r}.norm()
+}
+
+// Shift returns p*x^n.
+func (p Poly) Shift(n int) Poly {
+	if n < 0 {
+		panic("negative shift")
+	}
+	if len(p.w) == 0 {
+		return p
+	}
+	words, s := n>>6, uint(n&63)
+	r := make([]uint64, len(p.w)+words+1)
+	for i, w := range p.w {
+		r[i+words] |= w << s
+		if s != 0 {
+			r[i+words+1] |= w >> (64 - s)
+		}
+	}
+	return Poly{r}.norm()
+}
+
+// Mul returns p*q.
+func (p Poly) Mul(q Poly) Poly {
+	if len(p.w) == 0 || len(q.w) == 0 {
+		return Poly{}
+	}
+	r := make([]uint64, len(p.w)+len(q.w))
+	for i, a := range p.w {
+		for j, b := range q.w {
+			lo, hi := clmul(a, b)
+			r[i+j] ^= lo
+			r[i+j+1] ^= hi
+		}
+	}
+	return Poly{r}.norm()
+}
+
+// clmul returns the carry-less product of a and b.
+func clmul(a, b uint64) (lo, hi uint64) {
+	for ; a != 0; a &= a - 1 {
+		i := bits.TrailingZeros64(a)
+		lo ^= b << i
+		if i != 0 {
+			hi ^= b >> (64 - i)
+		}
+	}
+	return lo, hi
+}
+
+// DivMod returns the quotient and the remainder of p/q. It panics if q is
+// zero.
+func (p Poly) DivMod(q Poly) (quo, rem Poly) {
+	dq := q.Deg()
+	if dq < 0 {
+		panic("division by the zero polynomial")
+	}
+	dp := p.Deg()
+	if dp < dq {
+		return Poly{}, p
+	}
+	r := append([]uint64(nil), p.w...)
+	quoW := make([]uint64, (dp-dq)>>6+1)
+	for d := dp; d >= dq; d-- {
+		if r[d>>6]>>(d&63)&1 == 0 {
+			continue
+		}
+		s := d - dq
+		quoW[s>>6] |= 1 << (s & 63)
+		// r -= q*x^s
+		words, sh := s>>6, uint(s&63)
+		for i, w := range q.w {
+			r[i+words] ^= w << sh
+			if sh != 0 && i+words+1 < len(r) {
+				r[i+words+1] ^= w >> (64 - sh)
+			}
+		}
+	}
+	return Poly{quoW}.norm(), Poly{r}.norm()
+}
+
+// Div returns the quotient of p/q.
+func (p Poly) Div(q Poly) Poly {
+	quo, _ := p.DivMod(q)
+	return quo
+}
+
+// Mod returns the remainder of p/q.
+func (p Poly) Mod(q Poly) Poly {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// MulMod returns p*q mod m.
+func (p Poly) MulMod(q, m Poly) Poly {
+	return p.Mul(q).Mod(m)
+}
+
+// GCD returns the greatest common divisor of p and q.
+func GCD(p, q Poly) Poly {
+	for !q.IsZero() {
+		p, q = q, p.Mod(q)
+	}
+	return p
+}
+
+// XPowMod returns x^n mod m.
+func XPowMod(n uint64, m Poly) Poly {
+	return PowMod(Monomial(1), n, m)
+}
+
+// PowMod returns p^n mod m.
+func PowMod(p Poly, n uint64, m Poly) Poly {
+	r := One.Mod(m)
+	p = p.Mod(m)
+	for ; n != 0; n >>= 1 {
+		if n&1 != 0 {
+			r = r.MulMod(p, m)
+		}
+		p = p.MulMod(p, m)
+	}
+	return r
+}
+
+// Reverse returns the reciprocal polynomial x^deg(p) * p(1/x): the
+// polynomial with the coefficients in reverse order. The reverse of a
+// CRC poly is the poly of the same CRC with reflected input and output.
+func (p Poly) Reverse() Poly {
+	d := p.Deg()
+	if d < 0 {
+		return p
+	}
+	r := make([]uint64, len(p.w))
+	for i := 0; i <= d; i++ {
+		if p.Coeff(i) {
+			j := d - i
+			r[j>>6] |= 1 << (j & 63)
+		}
+	}
+	return Poly{r}.norm()
+}
+
+// Derivative returns the formal derivative of p.
+func (p Poly) Derivative() Poly {
+	// the derivative of x^i is i*x^(i-1) which is zero if i is even
+	// and a word starts with an even power so there is no carry between words
+	r := make([]uint64, len(p.w))
+	for i, w := range p.w {
+		r[i] = w & 0xaaaaaaaaaaaaaaaa >> 1 // the coefficients of x^1, x^3, ...
+	}
+	return Poly{r}.norm()
+}
+
+// String returns p in the format "x^16 + x^12 + x^5 + 1".
+func (p Poly) String() string {
+	if p.IsZero() {
+		return "0"
+	}
+	var sb strings.Builder
+	for i := p.Deg(); i >= 0; i-- {
+		if !p.Coeff(i) {
+			continue
+		}
+		if sb.Len() != 0 {
+			sb.WriteString(" + ")
+		}
+		switch i {
+		case 0:
+			sb.WriteString("1")
+		case 1:
+			sb.WriteString("x")
+		default:
+			sb.WriteString("x^" + strconv.Itoa(i))
+		}
+	}
+	return sb.String()
+}
+
+// Parse parses the output of Poly.String. Spaces are optional and terms can
+// be in any order (the same terms cancel out).
+func Parse(s string) (Poly, error) {
+	s = strings.ReplaceAll(s, " ", "")
+	if s == "0" {
+		return Poly{}, nil
+	}
+	var p Poly
+	for _, term := range strings.Split(s, "+") {
+		var n int
+		switch {
+		case term == "1":
+			n = 0
+		case term == "x":
+			n = 1
+		case strings.HasPrefix(term, "x^"):
+			var err error
+			if n, err = strconv.Atoi(term[2:]); err != nil || n < 0 {
+				return Poly{}, fmt.Errorf("invalid term %q", term)
+			}
+		default:
+			return Poly{}, fmt.Errorf("invalid term %q", term)
+		}
+		p = p.Add(Monomial(n))
+	}
+	return p, nil
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package gf2_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc/gf2"
+)
+
+func randPoly(rnd *rand.Rand, words int) gf2.Poly {
+	w := make([]uint64, words)
+	for i := range w {
+		w[i] = rnd.Uint64()
+	}
+	return gf2.New(w...)
+}
+
+func TestDivMod(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := randPoly(rnd, 1+rnd.Intn(4))
+		q := randPoly(rnd, 1+rnd.Intn(3)).Shift(rnd.Intn(64))
+		if q.IsZero() {
+			continue
+		}
+		quo, rem := p.DivMod(q)
+		if rem.Deg() >= q.Deg() {
+			t.Fatalf("deg(rem)=%d >= deg(q)=%d", rem.Deg(), q.Deg())
+		}
+		if got := quo.Mul(q).Add(rem); !got.Equal(p) {
+			t.Fatalf("quo*q+rem=%v, want %v", got, p)
+		}
+	}
+}
+
+func TestMul(t *testing.T) {
+	// (x+1)^2 = x^2+1
+	if got := gf2.FromUint64(3).Mul(gf2.FromUint64(3)); !got.Equal(gf2.FromUint64(5)) {
+		t.Errorf("(x+1)^2=%v", got)
+	}
+	// x^63 * x^64 = x^127
+	if got := gf2.Monomial(63).Mul(gf2.Monomial(64)); !got.Equal(gf2.Monomial(127)) {
+		t.Errorf("x^63*x^64=%v", got)
+	}
+	if got := gf2.FromUint64(3).Mul(gf2.Poly{}); !got.IsZero() {
+		t.Errorf("(x+1)*0=%v", got)
+	}
+}
+
+func TestGCD(t *testing.T) {
+	a := gf2.FromUint64(0b1011) // x^3+x+1
+	b := gf2.FromUint64(0b111)  // x^2+x+1
+	c := gf2.FromUint64(0b11)   // x+1
+	if got := gf2.GCD(a.Mul(c), b.Mul(c)); !got.Equal(c) {
+		t.Errorf("GCD=%v, want %v", got, c)
+	}
+	if got := gf2.GCD(a, b); !got.Equal(gf2.One) {
+		t.Errorf("GCD=%v, want 1", got)
+	}
+}
+
+func TestXPowMod(t *testing.T) {
+	m, _ := gf2.FromAlgo(16, 0x1021)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := rnd.Intn(300)
+		want := gf2.Monomial(n).Mod(m)
+		if got := gf2.XPowMod(uint64(n), m); !got.Equal(want) {
+			t.Errorf("x^%d mod m=%v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	// CRC-32 with reflected and normal notation
+	p, _ := gf2.FromAlgo(32, 0x04c11db7)
+	r := gf2.FromUint64(0xedb88320).Shift(1).Add(gf2.One)
+	if got := p.Reverse(); !got.Equal(r) {
+		t.Errorf("Reverse=%v, want %v", got, r)
+	}
+	if got := gf2.Monomial(100).Add(gf2.One).Reverse(); !got.Equal(gf2.Monomial(100).Add(gf2.One)) {
+		t.Errorf("Reverse=%v", got)
+	}
+}
+
+func TestDerivative(t *testing.T) {
+	p, _ := gf2.Parse("x^65 + x^64 + x^3 + x^2 + x")
+	want, _ := gf2.Parse("x^64 + x^2 + 1")
+	if got := p.Derivative(); !got.Equal(want) {
+		t.Errorf("Derivative=%v, want %v", got, want)
+	}
+}
+
+func TestAlgo(t *testing.T) {
+	tests := []struct {
+		width int
+		poly  uint64
+		str   string
+	}{
+		{16, 0x1021, "x^16 + x^12 + x^5 + 1"},
+		{3, 0x3, "x^3 + x + 1"},
+		{64, 0x1b, "x^64 + x^4 + x^3 + x + 1"},
+	}
+	for _, tc := range tests {
+		p, err := gf2.FromAlgo(tc.width, tc.poly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := p.String(); s != tc.str {
+			t.Errorf("String=%q, want %q", s, tc.str)
+		}
+		p2, err := gf2.Parse(tc.str)
+		if err != nil || !p2.Equal(p) {
+			t.Errorf("Parse(%q)=%v, %v", tc.str, p2, err)
+		}
+		width, poly, err := p.Algo()
+		if err != nil || width != tc.width || poly != tc.poly {
+			t.Errorf("Algo=%d, %#x, %v, want %d, %#x", width, poly, err, tc.width, tc.poly)
+		}
+	}
+	if _, err := gf2.FromAlgo(8, 0x100); err == nil {
+		t.Error("FromAlgo accepted a poly outside of the width")
+	}
+	if _, _, err := gf2.Monomial(65).Algo(); err == nil {
+		t.Error("Algo accepted a poly of degree 65")
+	}
+	if _, err := gf2.Parse("x^2 + y"); err == nil {
+		t.Error("Parse accepted an invalid term")
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"hash"
+	"io"
+)
+
+// NewHash returns a hash.Hash that calculates the CRC of the data written to
+// it with the given algorithm. The Size of the hash is the number of bytes
+// required to store a CRC of the algorithm's bit width (e.g. 1 for CRC-5/USB
+// and 5 for CRC-40/GSM). Sum appends the CRC in little-endian byte order if
+// the algorithm has refout=true and in big-endian byte order otherwise.
+//
+// The returned object also implements hash.Hash64, and hash.Hash32 if the
+// bit width of the algorithm is 32 or less (regardless of T) so the CRCs of
+// wider algorithms (e.g. CRC-40/GSM) can't be truncated by Sum32.
+func NewHash[T UInt](a Algo[T]) hash.Hash {
+	h := newHasher(a)
+	if a.Params().Width <= 32 {
+		return &hasher32[T]{h}
+	}
+	return h
+}
+
+// NewHash32 is the same as NewHash but returns a hash.Hash32.
+func NewHash32[T uint8 | uint16 | uint32](a Algo[T]) hash.Hash32 {
+	return &hasher32[T]{newHasher(a)}
+}
+
+// NewHash64 is the same as NewHash but returns a hash.Hash64.
+func NewHash64(a Algo[uint64]) hash.Hash64 {
+	return NewHash(a).(hash.Hash64)
+}
+
+func newHasher[T UInt](a Algo[T]) *hasher[T] {
+	params := a.Params()
+	return &hasher[T]{a.NewCRC(), (params.Width + 7) >> 3, params.RefOut}
+}
+
+type hasher[T UInt] struct {
+	c            CRC[T]
+	size         int
+	littleEndian bool
+}
+
+func (h *hasher[T]) Write(p []byte) (int, error) {
+	h.c.Update(p)
+	return len(p), nil
+}
+
+func (h *hasher[T]) Sum(b []byte) []byte {
+	v := uint64(h.c.Final())
+	for i := 0; i < h.size; i++ {
+		if h.littleEndian {
+			b = append(b, byte(v>>(i<<3)))
+		} else {
+			b = append(b, byte(v>>((h.size-1-i)<<3)))
+		}
+	}
+	return b
+}
+
+func (h *hasher[T]) Reset() {
+	h.c.Reset()
+}
+
+func (h *hasher[T]) Size() int {
+	return h.size
+}
+
+func (h *hasher[T]) BlockSize() int {
+	return 1
+}
+
+func (h *hasher[T]) Sum64() uint64 {
+	return uint64(h.c.Final())
+}
+
+// hasher32 is a hasher with a Sum32 method for algorithms with a bit width of
+// 32 or less.
+type hasher32[T UInt] struct {
+	*hasher[T]
+}
+
+func (h *hasher32[T]) Sum32() uint32 {
+	return uint32(h.c.Final())
+}
+
+// NewWriter returns an io.Writer that updates all of the crcs with the data
+// written to it. Unlike NewHash it can feed several CRCs from a single
+// io.Copy and it doesn't own them: their values are read with their own
+// methods.
+func NewWriter[T UInt](crcs ...CRC[T]) io.Writer {
+	return crcWriter[T](append([]CRC[T](nil), crcs...))
+}
+
+type crcWriter[T UInt] []CRC[T]
+
+func (w crcWriter[T]) Write(p []byte) (int, error) {
+	for _, c := range w {
+		c.Update(p)
+	}
+	return len(p), nil
+}
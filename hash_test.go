@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"bytes"
+	"hash"
+	"hash/crc32"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name string
+		h    hash.Hash
+		sum  []byte
+	}{
+		{"CRC5USB", crc.NewHash[uint8](crc.CRC5USB), []byte{0x19}},
+		{"CRC16XMODEM", crc.NewHash[uint16](crc.CRC16XMODEM), []byte{0x31, 0xc3}},
+		{"CRC16ARC", crc.NewHash[uint16](crc.CRC16ARC), []byte{0x3d, 0xbb}},
+		{"CRC32ISOHDLC", crc.NewHash[uint32](crc.CRC32ISOHDLC), []byte{0x26, 0x39, 0xf4, 0xcb}},
+		{"CRC40GSM", crc.NewHash[uint64](crc.CRC40GSM), []byte{0xd4, 0x16, 0x4f, 0xc6, 0x46}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.h.Size() != len(tc.sum) {
+				t.Errorf("size=%d, want %d", tc.h.Size(), len(tc.sum))
+			}
+			if _, err := io.Copy(tc.h, strings.NewReader("123456789")); err != nil {
+				t.Fatal(err)
+			}
+			prefix := []byte("prefix")
+			if sum := tc.h.Sum(prefix); !bytes.Equal(sum, append(prefix, tc.sum...)) {
+				t.Errorf("sum=%x, want %x", sum, tc.sum)
+			}
+			tc.h.Reset()
+			tc.h.Write([]byte("1234"))
+			tc.h.Write([]byte("56789"))
+			if sum := tc.h.Sum(nil); !bytes.Equal(sum, tc.sum) {
+				t.Errorf("sum after reset=%x, want %x", sum, tc.sum)
+			}
+		})
+	}
+}
+
+func TestHash32(t *testing.T) {
+	h := crc.NewHash32(crc.CRC32.Algo())
+	h.Write([]byte("hello, world"))
+	if got, want := h.Sum32(), crc32.ChecksumIEEE([]byte("hello, world")); got != want {
+		t.Errorf("sum32=%x, want %x", got, want)
+	}
+}
+
+func TestHash64(t *testing.T) {
+	h := crc.NewHash64(crc.CRC64XZ.Algo())
+	h.Write([]byte("123456789"))
+	if got, want := h.Sum64(), uint64(0x995dc9bbdf1939fa); got != want {
+		t.Errorf("sum64=%x, want %x", got, want)
+	}
+}
+
+func TestHashInterfaces(t *testing.T) {
+	if _, ok := crc.NewHash[uint64](crc.CRC40GSM).(hash.Hash32); ok {
+		t.Error("the hash of CRC-40/GSM implements hash.Hash32")
+	}
+	if _, ok := crc.NewHash[uint64](crc.CRC40GSM).(hash.Hash64); !ok {
+		t.Error("the hash of CRC-40/GSM doesn't implement hash.Hash64")
+	}
+	a, err := crc.NewAlgo[uint64](32, 0x04c11db7, 0xffffffff, 0xffffffff, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, ok := crc.NewHash(a).(hash.Hash32)
+	if !ok {
+		t.Fatal("the hash of a 32-bit algorithm with T=uint64 doesn't implement hash.Hash32")
+	}
+	h.Write([]byte("123456789"))
+	if got := h.Sum32(); got != 0xcbf43926 {
+		t.Errorf("sum32=%x, want cbf43926", got)
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	c1, c2 := crc.CRC32.NewCRC(), crc.CRC32C.NewCRC()
+	if _, err := io.Copy(crc.NewWriter(c1, c2), strings.NewReader("123456789")); err != nil {
+		t.Fatal(err)
+	}
+	if c1.Final() != 0xcbf43926 || c2.Final() != 0xe3069283 {
+		t.Errorf("crcs=%#x %#x", c1.Final(), c2.Final())
+	}
+}
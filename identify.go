@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+// Match is a preset that reproduces a stored CRC.
+type Match struct {
+	Preset *PresetInfo
+
+	// LittleEndian is true if the CRC is stored in little-endian byte order.
+	// It's always false for CRCs that fit in a single byte.
+	LittleEndian bool
+
+	// Leading is true if IdentifyFrame found the CRC at the beginning of the
+	// frame (in front of the data) instead of its end.
+	Leading bool
+}
+
+// Identify returns the presets that calculate the CRC stored in crc from
+// data. The CRC is tried in both byte orders and has to occupy exactly the
+// number of bytes required by the bit width of the preset (e.g. 4 bytes for
+// CRC-32 and 5 bytes for CRC-40/GSM). Only the presets listed by Presets are
+// tried: the presets wider than 64 bits (Presets128) aren't supported.
+func Identify(data, crc []byte) []Match {
+	var matches []Match
+	for _, p := range presetInfos {
+		matches = p.identify(matches, data, crc, false)
+	}
+	return matches
+}
+
+// IdentifyFrame returns the presets that calculate the CRC found at the end
+// or at the beginning of the frame from the rest of the frame. Like Identify
+// it tries only the presets listed by Presets.
+func IdentifyFrame(frame []byte) []Match {
+	var matches []Match
+	for _, p := range presetInfos {
+		n := (p.Params.Width + 7) >> 3
+		if len(frame) < n {
+			continue
+		}
+		matches = p.identify(matches, frame[:len(frame)-n], frame[len(frame)-n:], false)
+		matches = p.identify(matches, frame[n:], frame[:n], true)
+	}
+	return matches
+}
+
+func (p *PresetInfo) identify(matches []Match, data, crc []byte, leading bool) []Match {
+	if len(crc) != (p.Params.Width+7)>>3 {
+		return matches
+	}
+	var be, le uint64
+	for i, b := range crc {
+		be = be<<8 | uint64(b)
+		le |= uint64(b) << (i << 3)
+	}
+	c := p.Algo.Calc(data)
+	if c == be {
+		matches = append(matches, Match{p, false, leading})
+	}
+	if c == le && len(crc) > 1 {
+		matches = append(matches, Match{p, true, leading})
+	}
+	return matches
+}
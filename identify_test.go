@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+// storeCRC returns the CRC in the minimum number of bytes.
+func storeCRC(v uint64, width int, littleEndian bool) []byte {
+	n := (width + 7) >> 3
+	b := make([]byte, n)
+	for i := range b {
+		if littleEndian {
+			b[i] = byte(v >> (i << 3))
+		} else {
+			b[n-1-i] = byte(v >> (i << 3))
+		}
+	}
+	return b
+}
+
+func hasMatch(matches []crc.Match, name string, littleEndian, leading bool) bool {
+	for _, m := range matches {
+		if m.Preset.Name == name && m.LittleEndian == littleEndian && m.Leading == leading {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIdentify(t *testing.T) {
+	data := []byte("123456789")
+	for _, p := range crc.Presets() {
+		for _, le := range []bool{false, true} {
+			if le && p.Params.Width <= 8 {
+				continue
+			}
+			matches := crc.Identify(data, storeCRC(p.Algo.Calc(data), p.Params.Width, le))
+			if !hasMatch(matches, p.Name, le, false) {
+				t.Errorf("%s littleEndian=%t: matches=%v", p.Name, le, matches)
+			}
+			for _, m := range matches {
+				if m.Preset.Algo.Calc(data) != p.Algo.Calc(data) {
+					t.Errorf("%s littleEndian=%t: false match %s", p.Name, le, m.Preset.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestIdentifyFrame(t *testing.T) {
+	data := []byte("Hello, World!")
+	p, _ := crc.LookupPreset("CRC-32/ISO-HDLC")
+	stored := storeCRC(p.Algo.Calc(data), 32, true)
+
+	matches := crc.IdentifyFrame(append(append([]byte(nil), data...), stored...))
+	if len(matches) != 1 || !hasMatch(matches, p.Name, true, false) {
+		t.Errorf("trailing: matches=%v", matches)
+	}
+	matches = crc.IdentifyFrame(append(append([]byte(nil), stored...), data...))
+	if len(matches) != 1 || !hasMatch(matches, p.Name, true, true) {
+		t.Errorf("leading: matches=%v", matches)
+	}
+	if matches := crc.IdentifyFrame(data); len(matches) != 0 {
+		t.Errorf("no CRC: matches=%v", matches)
+	}
+}
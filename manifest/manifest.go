@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+// Package manifest reads, writes and verifies checksum manifests: files that
+// list the CRCs of other files. Three formats are supported:
+//
+//   - SFV (Simple File Verification): "path CRC" lines where CRC is the
+//     CRC-32/ISO-HDLC of the file in 8 hex digits. Lines starting with ';'
+//     are comments.
+//   - Cksum: "CRC size path" lines in the output format of the POSIX cksum
+//     utility where CRC is the decimal cksum checksum.
+//   - Sum: "CRC  path" lines like the output of sha256sum where CRC is in
+//     hex and the algorithm is chosen by the user.
+package manifest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+// Format is the format of a manifest.
+type Format int
+
+const (
+	SFV Format = iota
+	Cksum
+	Sum
+)
+
+func (f Format) String() string {
+	switch f {
+	case SFV:
+		return "sfv"
+	case Cksum:
+		return "cksum"
+	case Sum:
+		return "sum"
+	}
+	return "Format(" + strconv.Itoa(int(f)) + ")"
+}
+
+// ParseFormat parses the result of Format.String.
+func ParseFormat(s string) (Format, error) {
+	for _, f := range []Format{SFV, Cksum, Sum} {
+		if f.String() == s {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown manifest format %q", s)
+}
+
+// Entry is a line of a manifest.
+type Entry struct {
+	Path string
+	CRC  uint64
+	Size int64 // the size of the file, stored only by the Cksum format
+}
+
+// Manifest is a list of files with their CRCs.
+type Manifest struct {
+	Format Format
+
+	// Algo is the algorithm of the Sum format. It's ignored by the other
+	// formats because they define their own algorithms.
+	Algo crc.AnyAlgo
+
+	Entries []Entry
+}
+
+// New creates an empty manifest. The algo is used only by the Sum format.
+func New(format Format, algo crc.AnyAlgo) (*Manifest, error) {
+	m := &Manifest{Format: format, Algo: algo}
+	switch format {
+	case SFV, Cksum:
+	case Sum:
+		if algo == nil {
+			return nil, errors.New("the sum format requires an algorithm")
+		}
+	default:
+		return nil, fmt.Errorf("invalid manifest format %d", format)
+	}
+	return m, nil
+}
+
+// Read parses a manifest.
+func Read(r io.Reader, format Format, algo crc.AnyAlgo) (*Manifest, error) {
+	m, err := New(format, algo)
+	if err != nil {
+		return nil, err
+	}
+	s := bufio.NewScanner(r)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		line := strings.TrimSuffix(s.Text(), "\r")
+		if line == "" || format == SFV && line[0] == ';' {
+			continue
+		}
+		e, err := m.parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+		m.Entries = append(m.Entries, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Manifest) parseLine(line string) (Entry, error) {
+	var e Entry
+	var err error
+	switch m.Format {
+	case SFV:
+		i := strings.LastIndexByte(line, ' ')
+		if i < 0 || len(line)-i-1 != 8 {
+			return e, errors.New("invalid SFV line")
+		}
+		e.Path = strings.TrimRight(line[:i], " ")
+		e.CRC, err = strconv.ParseUint(line[i+1:], 16, 32)
+	case Cksum:
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 {
+			return e, errors.New("invalid cksum line")
+		}
+		e.Path = fields[2]
+		if e.CRC, err = strconv.ParseUint(fields[0], 10, 32); err == nil {
+			e.Size, err = strconv.ParseInt(fields[1], 10, 64)
+		}
+	case Sum:
+		// the second separator character is '*' in binary mode
+		digits := m.digits()
+		if len(line) < digits+3 || line[digits] != ' ' || line[digits+1] != ' ' && line[digits+1] != '*' {
+			return e, errors.New("invalid sum line")
+		}
+		e.Path = line[digits+2:]
+		if e.CRC, err = strconv.ParseUint(line[:digits], 16, 64); err == nil && e.CRC>>m.Algo.Params().Width != 0 {
+			err = errors.New("CRC out of range")
+		}
+	}
+	if err == nil && e.Path == "" {
+		err = errors.New("missing path")
+	}
+	return e, err
+}
+
+// digits returns the number of hex digits of the CRCs in the Sum format.
+func (m *Manifest) digits() int {
+	return (m.Algo.Params().Width + 3) >> 2
+}
+
+// WriteTo writes the manifest in its format.
+func (m *Manifest) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	for _, e := range m.Entries {
+		var k int
+		var err error
+		switch m.Format {
+		case SFV:
+			k, err = fmt.Fprintf(bw, "%s %08X\n", e.Path, e.CRC)
+		case Cksum:
+			k, err = fmt.Fprintf(bw, "%d %d %s\n", e.CRC, e.Size, e.Path)
+		case Sum:
+			k, err = fmt.Fprintf(bw, "%0*x  %s\n", m.digits(), e.CRC, e.Path)
+		}
+		n += int64(k)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, bw.Flush()
+}
+
+// Calc calculates the CRC of the data read from r with the algorithm of the
+// manifest's format.
+func (m *Manifest) Calc(r io.Reader) (c uint64, size int64, err error) {
+	switch m.Format {
+	case SFV:
+		return calc(r, crc32ISOHDLC)
+	case Cksum:
+		return cksum(r)
+	}
+	return calc(r, m.Algo)
+}
+
+var crc32ISOHDLC = crc.CRC32ISOHDLC.Any()
+
+func calc(r io.Reader, a crc.AnyAlgo) (uint64, int64, error) {
+	c := a.NewCRC()
+	size, err := io.Copy(crc.NewWriter(c), r)
+	return c.Final(), size, err
+}
+
+func cksum(r io.Reader) (uint64, int64, error) {
+	c := crc.NewCksum()
+	size, err := io.Copy(c, r)
+	return uint64(c.Sum32()), size, err
+}
+
+// Add calculates the CRC of a file and appends it to the manifest.
+func (m *Manifest) Add(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	c, size, err := m.Calc(f)
+	if err != nil {
+		return err
+	}
+	m.Entries = append(m.Entries, Entry{filepath.ToSlash(path), c, size})
+	return nil
+}
+
+// Status is the result of verifying an entry.
+type Status int
+
+const (
+	OK      Status = iota
+	Failed         // the CRC doesn't match or the file couldn't be read
+	Missing        // the file doesn't exist
+)
+
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "OK"
+	case Failed:
+		return "FAILED"
+	case Missing:
+		return "MISSING"
+	}
+	return "Status(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Result is the result of verifying an entry.
+type Result struct {
+	Entry
+	Status Status
+	Err    error // set if the file couldn't be read
+}
+
+// Verify checks the files listed in the manifest. Relative paths are
+// resolved relative to dir.
+func (m *Manifest) Verify(dir string) []Result {
+	results := make([]Result, len(m.Entries))
+	for i, e := range m.Entries {
+		results[i] = m.verify(dir, e)
+	}
+	return results
+}
+
+func (m *Manifest) verify(dir string, e Entry) Result {
+	path := filepath.FromSlash(e.Path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Result{e, Missing, err}
+		}
+		return Result{e, Failed, err}
+	}
+	defer f.Close()
+	c, size, err := m.Calc(f)
+	if err != nil {
+		return Result{e, Failed, err}
+	}
+	if c != e.CRC || m.Format == Cksum && size != e.Size {
+		return Result{e, Failed, nil}
+	}
+	return Result{e, OK, nil}
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package manifest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+	"github.com/pasztorpisti/go-crc/manifest"
+)
+
+func TestCalc(t *testing.T) {
+	tests := []struct {
+		format manifest.Format
+		data   string
+		crc    uint64
+	}{
+		{manifest.SFV, "123456789", 0xcbf43926},
+		{manifest.Cksum, "123456789", 930766865},
+		{manifest.Cksum, "", 4294967295},
+		{manifest.Sum, "123456789", 0x31c3},
+	}
+	for _, tc := range tests {
+		m, err := manifest.New(tc.format, crc.CRC16XMODEM.Any())
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, size, err := m.Calc(strings.NewReader(tc.data))
+		if err != nil || c != tc.crc || size != int64(len(tc.data)) {
+			t.Errorf("%v %q: crc=%d size=%d err=%v, want crc=%d", tc.format, tc.data, c, size, err, tc.crc)
+		}
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	tests := []struct {
+		format  manifest.Format
+		text    string
+		entries []manifest.Entry
+	}{
+		{manifest.SFV, "; comment\r\na.txt CBF43926\r\ndir/b c.txt 00000000\r\n", []manifest.Entry{
+			{Path: "a.txt", CRC: 0xcbf43926},
+			{Path: "dir/b c.txt", CRC: 0},
+		}},
+		{manifest.Cksum, "930766865 9 a.txt\n4294967295 0 dir/b c.txt\n", []manifest.Entry{
+			{Path: "a.txt", CRC: 930766865, Size: 9},
+			{Path: "dir/b c.txt", CRC: 4294967295},
+		}},
+		{manifest.Sum, "31c3  a.txt\n0000 *dir/b c.txt\n", []manifest.Entry{
+			{Path: "a.txt", CRC: 0x31c3},
+			{Path: "dir/b c.txt", CRC: 0},
+		}},
+	}
+	for _, tc := range tests {
+		m, err := manifest.Read(strings.NewReader(tc.text), tc.format, crc.CRC16XMODEM.Any())
+		if err != nil {
+			t.Errorf("%v: %v", tc.format, err)
+			continue
+		}
+		if !reflect.DeepEqual(m.Entries, tc.entries) {
+			t.Errorf("%v: entries=%+v, want %+v", tc.format, m.Entries, tc.entries)
+		}
+		var buf bytes.Buffer
+		if _, err := m.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		m2, err := manifest.Read(&buf, tc.format, crc.CRC16XMODEM.Any())
+		if err != nil || !reflect.DeepEqual(m2.Entries, tc.entries) {
+			t.Errorf("%v: round trip: entries=%+v err=%v", tc.format, m2, err)
+		}
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tests := []struct {
+		format manifest.Format
+		text   string
+	}{
+		{manifest.SFV, "a.txt CBF4392\n"},
+		{manifest.SFV, "CBF43926\n"},
+		{manifest.Cksum, "930766865 a.txt\n"},
+		{manifest.Cksum, "930766865 x a.txt\n"},
+		{manifest.Sum, "31c3 a.txt\n"},
+		{manifest.Sum, "31c3x  a.txt\n"},
+		{manifest.Sum, "31c3  \n"},
+	}
+	for _, tc := range tests {
+		if _, err := manifest.Read(strings.NewReader(tc.text), tc.format, crc.CRC16XMODEM.Any()); err == nil {
+			t.Errorf("%v %q: no error", tc.format, tc.text)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{"ok": "123456789", "bad": "12345678"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, format := range []manifest.Format{manifest.SFV, manifest.Cksum, manifest.Sum} {
+		m, err := manifest.New(format, crc.CRC16XMODEM.Any())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := m.Add(filepath.Join(dir, "ok")); err != nil {
+			t.Fatal(err)
+		}
+		m.Entries[0].Path = "ok"
+		m.Entries = append(m.Entries, manifest.Entry{Path: "bad", CRC: m.Entries[0].CRC, Size: 9},
+			manifest.Entry{Path: "missing", CRC: m.Entries[0].CRC, Size: 9})
+		results := m.Verify(dir)
+		want := []manifest.Status{manifest.OK, manifest.Failed, manifest.Missing}
+		for i, r := range results {
+			if r.Status != want[i] {
+				t.Errorf("%v %s: status=%v, want %v", format, r.Path, r.Status, want[i])
+			}
+		}
+	}
+}
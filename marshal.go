@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The marshaled state of a CRC instance:
+//
+//	magic [4]byte // "crc\x01"
+//	width byte
+//	flags byte    // bit0: refin, bit1: refout
+//	poly, init, xorout, reg, bitLen uint64 (big-endian)
+//
+// Poly and init are stored in (unreflected) MSB-first format like the
+// parameters of NewAlgo and reg holds the reflected shift register.
+const (
+	marshalMagic      = "crc\x01"
+	marshalHeaderSize = len(marshalMagic) + 2 + 3*8
+	marshalSize       = marshalHeaderSize + 2*8
+)
+
+func (c *crc[T]) MarshalBinary() ([]byte, error) {
+	b := make([]byte, marshalSize)
+	c.a.marshalHeader(b)
+	binary.BigEndian.PutUint64(b[marshalHeaderSize:], uint64(c.reg))
+	binary.BigEndian.PutUint64(b[marshalHeaderSize+8:], uint64(c.bitLen))
+	return b, nil
+}
+
+func (c *crc[T]) UnmarshalBinary(b []byte) error {
+	if len(b) != marshalSize || string(b[:len(marshalMagic)]) != marshalMagic {
+		return errors.New("invalid CRC state")
+	}
+	h := make([]byte, marshalHeaderSize)
+	c.a.marshalHeader(h)
+	if string(b[:marshalHeaderSize]) != string(h) {
+		return errors.New("the CRC state belongs to a different algorithm")
+	}
+	reg := binary.BigEndian.Uint64(b[marshalHeaderSize:])
+	bitLen := int64(binary.BigEndian.Uint64(b[marshalHeaderSize+8:]))
+	if reg > uint64((T(1)<<c.a.width)-1) || bitLen < 0 {
+		return errors.New("invalid CRC state")
+	}
+	c.reg, c.bitLen = T(reg), bitLen
+	return nil
+}
+
+// marshalHeader writes the magic and the parameters of the algorithm to
+// the beginning of b.
+func (a *algo[T]) marshalHeader(b []byte) {
+	n := copy(b, marshalMagic)
+	b[n] = byte(a.width)
+	b[n+1] = 0
+	if a.refin {
+		b[n+1] |= 1
+	}
+	if a.refout {
+		b[n+1] |= 2
+	}
+	n += 2
+	params := a.Params()
+	binary.BigEndian.PutUint64(b[n:], uint64(params.Poly))
+	binary.BigEndian.PutUint64(b[n+8:], uint64(params.Init))
+	binary.BigEndian.PutUint64(b[n+16:], uint64(params.XorOut))
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			c := p.preset.NewCRC()
+			c.Update([]byte("12345"))
+			state, err := c.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c2 := p.preset.NewCRC()
+			c2.Update([]byte("garbage"))
+			if err := c2.UnmarshalBinary(state); err != nil {
+				t.Fatal(err)
+			}
+			c2.Update([]byte("6789"))
+			if got := c2.Final(); got != p.check {
+				t.Errorf("check=%x, want %x", got, p.check)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	c := crc.CRC32ISOHDLC.NewCRC()
+	c.Update([]byte("1234"))
+	state, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, other := range []crc.CRC[uint32]{crc.CRC32BZIP2.NewCRC(), crc.CRC32JAMCRC.NewCRC(), crc.CRC32ISCSI.NewCRC()} {
+		if err := other.UnmarshalBinary(state); err == nil {
+			t.Error("expected an error for a state of a different algorithm")
+		}
+	}
+	if err := crc.CRC32ISOHDLC.NewCRC().UnmarshalBinary(state[:len(state)-1]); err == nil {
+		t.Error("expected an error for a truncated state")
+	}
+	if err := crc.CRC16ARC.NewCRC().UnmarshalBinary(state); err == nil {
+		t.Error("expected an error for a state of a different width")
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelOptions configures CalcParallel and CalcReaderAt. The zero value
+// (or a nil pointer) selects the defaults.
+type ParallelOptions struct {
+	// Workers is the number of goroutines that calculate the CRCs of the
+	// chunks. The default is runtime.GOMAXPROCS(0).
+	Workers int
+
+	// ChunkSize is the number of bytes processed by a goroutine at once.
+	// Cancellation is checked between chunks. The default is 1 MiB.
+	ChunkSize int
+
+	// Progress is called with the number of bytes processed so far after
+	// each chunk. It's called from the worker goroutines but never
+	// concurrently and the reported values are increasing.
+	Progress func(done int64)
+}
+
+const defaultChunkSize = 1 << 20
+
+// CalcParallel returns the CRC of data calculated by several goroutines. The
+// CRCs of the chunks are merged with Combine so the result is the same as
+// that of a.Calc(data). It returns the error of ctx if ctx is cancelled
+// before the calculation is finished.
+func CalcParallel[T UInt](ctx context.Context, a Algo[T], data []byte, opts *ParallelOptions) (T, error) {
+	return calcParallel(ctx, a, int64(len(data)), opts, func(_ *[]byte, off, n int64) ([]byte, error) {
+		return data[off : off+n], nil
+	})
+}
+
+// CalcReaderAt returns the CRC of the first size bytes of r (e.g. a file)
+// calculated by several goroutines like CalcParallel. Each goroutine reads
+// its chunks with r.ReadAt into its own buffer. Reading fewer than size bytes
+// is an error.
+func CalcReaderAt[T UInt](ctx context.Context, a Algo[T], r io.ReaderAt, size int64, opts *ParallelOptions) (T, error) {
+	if size < 0 {
+		panic("size is negative")
+	}
+	return calcParallel(ctx, a, size, opts, func(buf *[]byte, off, n int64) ([]byte, error) {
+		if int64(len(*buf)) < n {
+			*buf = make([]byte, n)
+		}
+		m, err := r.ReadAt((*buf)[:n], off)
+		if int64(m) == n {
+			// ReadAt is allowed to return io.EOF with the last bytes
+			return (*buf)[:n], nil
+		}
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	})
+}
+
+// calcParallel calculates the CRCs of the chunks of size bytes returned by
+// read on the worker goroutines and combines them. The buf parameter of read
+// points to a reusable buffer of the goroutine that is initially nil: read
+// allocates it if it has to copy the chunk.
+func calcParallel[T UInt](ctx context.Context, a Algo[T], size int64, opts *ParallelOptions,
+	read func(buf *[]byte, off, n int64) ([]byte, error)) (T, error) {
+	var o ParallelOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Workers <= 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = defaultChunkSize
+	}
+	chunkSize := int64(o.ChunkSize)
+	numChunks := (size + chunkSize - 1) / chunkSize
+	if int64(o.Workers) > numChunks {
+		o.Workers = int(numChunks)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	crcs := make([]T, numChunks)
+	var (
+		next     int64 // the index of the next chunk to process
+		mu       sync.Mutex
+		firstErr error
+		done     int64
+		finished int64 // the number of processed chunks
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < o.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var buf []byte
+			for ctx.Err() == nil {
+				i := atomic.AddInt64(&next, 1) - 1
+				if i >= numChunks {
+					return
+				}
+				off := i * chunkSize
+				n := size - off
+				if n > chunkSize {
+					n = chunkSize
+				}
+				chunk, err := read(&buf, off, n)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					cancel()
+					return
+				}
+				crcs[i] = a.Calc(chunk)
+				atomic.AddInt64(&finished, 1)
+				if o.Progress != nil {
+					mu.Lock()
+					done += n
+					o.Progress(done)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return 0, firstErr
+	}
+	if finished < numChunks {
+		// the workers stop early only if ctx is cancelled
+		return 0, ctx.Err()
+	}
+
+	if numChunks == 0 {
+		return a.Calc(nil), nil
+	}
+	// all chunks but the last one have the same size so they share x^n
+	combineChunk := combinerOf(a, chunkSize)
+	c := crcs[0]
+	for i := int64(1); i < numChunks-1; i++ {
+		c = combineChunk(c, crcs[i])
+	}
+	if numChunks > 1 {
+		c = a.Combine(c, crcs[numChunks-1], size-(numChunks-1)*chunkSize)
+	}
+	return c, nil
+}
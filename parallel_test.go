@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestCalcParallel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	data := make([]byte, 100000)
+	rnd.Read(data)
+	ctx := context.Background()
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			for _, size := range []int{0, 1, 1000, 4096, len(data)} {
+				want := p.preset.Calc(data[:size])
+				for _, opts := range []*crc.ParallelOptions{nil, {Workers: 1}, {Workers: 3, ChunkSize: 1000}, {Workers: 8, ChunkSize: 333}} {
+					got, err := crc.CalcParallel(ctx, p.preset, data[:size], opts)
+					if err != nil || got != want {
+						t.Errorf("size=%d opts=%+v: crc=%#x err=%v, want %#x", size, opts, got, err, want)
+					}
+					got, err = crc.CalcReaderAt(ctx, p.preset, bytes.NewReader(data), int64(size), opts)
+					if err != nil || got != want {
+						t.Errorf("ReaderAt size=%d opts=%+v: crc=%#x err=%v, want %#x", size, opts, got, err, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCalcParallelProgress(t *testing.T) {
+	data := make([]byte, 10000)
+	var last int64
+	calls := 0
+	opts := &crc.ParallelOptions{Workers: 4, ChunkSize: 999, Progress: func(done int64) {
+		if done <= last {
+			t.Errorf("progress %d after %d", done, last)
+		}
+		last = done
+		calls++
+	}}
+	if _, err := crc.CalcParallel(context.Background(), crc.CRC32.Algo(), data, opts); err != nil {
+		t.Fatal(err)
+	}
+	if last != int64(len(data)) || calls != 11 {
+		t.Errorf("progress=%d calls=%d, want %d 11", last, calls, len(data))
+	}
+}
+
+func TestCalcParallelCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	data := make([]byte, 10000)
+	opts := &crc.ParallelOptions{Workers: 2, ChunkSize: 100, Progress: func(done int64) {
+		if done >= 1000 {
+			cancel()
+		}
+	}}
+	if _, err := crc.CalcParallel(ctx, crc.CRC32.Algo(), data, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("err=%v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCalcParallelCancelAfterLastChunk(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	data := make([]byte, 10000)
+	opts := &crc.ParallelOptions{Workers: 2, ChunkSize: 100, Progress: func(done int64) {
+		if done == int64(len(data)) {
+			cancel()
+		}
+	}}
+	got, err := crc.CalcParallel(ctx, crc.CRC32.Algo(), data, opts)
+	if err != nil || got != crc.CRC32.Calc(data) {
+		t.Errorf("crc=%#x err=%v, want %#x", got, err, crc.CRC32.Calc(data))
+	}
+}
+
+// wrappedAlgo is an Algo implemented outside the package.
+type wrappedAlgo struct {
+	crc.Algo[uint32]
+}
+
+func TestCalcParallelWrappedAlgo(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.New(rand.NewSource(42)).Read(data)
+	opts := &crc.ParallelOptions{Workers: 3, ChunkSize: 999}
+	got, err := crc.CalcParallel[uint32](context.Background(), wrappedAlgo{crc.CRC32C.Algo()}, data, opts)
+	if err != nil || got != crc.CRC32C.Calc(data) {
+		t.Errorf("crc=%#x err=%v, want %#x", got, err, crc.CRC32C.Calc(data))
+	}
+}
+
+func TestCalcReaderAtShort(t *testing.T) {
+	r := bytes.NewReader(make([]byte, 1000))
+	opts := &crc.ParallelOptions{ChunkSize: 300}
+	if _, err := crc.CalcReaderAt(context.Background(), crc.CRC32.Algo(), r, 1001, opts); err == nil {
+		t.Error("no error")
+	}
+}
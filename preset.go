@@ -19,6 +19,7 @@ import "sync"
 type Preset[T UInt] interface {
 	Algo[T]
 	Algo() Algo[T]
+	Any() AnyAlgo // Any returns the preset as an AnyAlgo (see ToAny)
 }
 
 // NewPreset creates a preset or returns an error in case of invalid parameters.
@@ -64,6 +65,42 @@ func (p *preset[T]) CalcBits(data []byte, bitLen int) T {
 	return p.Algo().CalcBits(data, bitLen)
 }
 
+func (p *preset[T]) Combine(crc1, crc2 T, len2 int64) T {
+	return p.Algo().Combine(crc1, crc2, len2)
+}
+
+func (p *preset[T]) CombineBits(crc1, crc2 T, bitLen2 int64) T {
+	return p.Algo().CombineBits(crc1, crc2, bitLen2)
+}
+
+func (p *preset[T]) combiner(len2 int64) func(crc1, crc2 T) T {
+	return combinerOf(p.Algo(), len2)
+}
+
+func (p *preset[T]) Patch(crc T, length, offset int64, oldData, newData []byte) T {
+	return p.Algo().Patch(crc, length, offset, oldData, newData)
+}
+
+func (p *preset[T]) Encode(data []byte, bitLen int) ([]byte, int) {
+	return p.Algo().Encode(data, bitLen)
+}
+
+func (p *preset[T]) Verify(codeword []byte, bitLen int) bool {
+	return p.Algo().Verify(codeword, bitLen)
+}
+
+func (p *preset[T]) Residue() T {
+	return p.Algo().Residue()
+}
+
+func (p *preset[T]) Params() Params[T] {
+	return Params[T]{p.width, p.poly, p.init, p.xorout, p.refin, p.refout}
+}
+
+func (p *preset[T]) Any() AnyAlgo {
+	return ToAny[T](p)
+}
+
 func (p *preset[T]) Algo() Algo[T] {
 	p.algoOnce.Do(func() {
 		a, err := NewAlgo(p.width, p.poly, p.init, p.xorout, p.refin, p.refout)
@@ -239,4 +276,6 @@ var (
 	CRC64WE      = mustNewPreset[uint64](64, 0x42f0e1eba9ea3693, 0xffffffffffffffff, 0xffffffffffffffff, false, false) // CRC-64/WE
 	CRC64XZ      = mustNewPreset[uint64](64, 0x42f0e1eba9ea3693, 0xffffffffffffffff, 0xffffffffffffffff, true, true)   // CRC-64/XZ        Alias: CRC-64/GO-ECMA
 	CRC64REDIS   = mustNewPreset[uint64](64, 0xad93d23594c935a9, 0x0000000000000000, 0x0000000000000000, true, true)   // CRC-64/REDIS
+
+	CRC82DARC = mustNewPreset128(82, Uint128{0x308c, 0x0111011401440411}, Uint128{}, Uint128{}, true, true) // CRC-82/DARC
 )
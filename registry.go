@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PresetInfo describes one of the presets of this package.
+type PresetInfo struct {
+	Name    string         // official name in the CRC catalogue (e.g. "CRC-32/ISO-HDLC")
+	Aliases []string       // alternative names listed in the CRC catalogue
+	Params  Params[uint64] // parameters of the algorithm
+	Algo    AnyAlgo        // the preset itself
+}
+
+// LookupPreset finds a preset by its official name or one of its aliases in
+// the CRC catalogue. The name is case-insensitive and the characters other
+// than letters and digits are ignored so "crc32isohdlc", "CRC-32/ISO-HDLC"
+// and "CRC32ISOHDLC" (the name of the Go variable) are all equivalent.
+func LookupPreset(name string) (*PresetInfo, bool) {
+	p, ok := presetsByName[normalizePresetName(name)]
+	return p, ok
+}
+
+// Presets returns the descriptors of all presets sorted by bit width.
+// The returned slice can be modified by the caller. The presets wider than
+// 64 bits (e.g. CRC82DARC) are listed by Presets128.
+func Presets() []*PresetInfo {
+	return append([]*PresetInfo(nil), presetInfos...)
+}
+
+// PresetInfo128 describes one of the presets wider than 64 bits.
+type PresetInfo128 struct {
+	Name    string    // official name in the CRC catalogue (e.g. "CRC-82/DARC")
+	Aliases []string  // alternative names listed in the CRC catalogue
+	Algo    Preset128 // the preset itself
+}
+
+// LookupPreset128 is the counterpart of LookupPreset for the presets wider
+// than 64 bits. The names of the two kinds of presets don't overlap.
+func LookupPreset128(name string) (*PresetInfo128, bool) {
+	p, ok := presets128ByName[normalizePresetName(name)]
+	return p, ok
+}
+
+// Presets128 returns the descriptors of the presets wider than 64 bits
+// sorted by bit width. The returned slice can be modified by the caller.
+func Presets128() []*PresetInfo128 {
+	return append([]*PresetInfo128(nil), presetInfos128...)
+}
+
+func newPresetInfo[T UInt](p Preset[T], name string, aliases ...string) *PresetInfo {
+	a := p.Any()
+	return &PresetInfo{name, aliases, a.Params(), a}
+}
+
+func normalizePresetName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, name)
+}
+
+var presetsByName = func() map[string]*PresetInfo {
+	m := make(map[string]*PresetInfo)
+	for _, p := range presetInfos {
+		for _, name := range append([]string{p.Name}, p.Aliases...) {
+			key := normalizePresetName(name)
+			if _, ok := m[key]; ok {
+				panic("duplicate preset name: " + name)
+			}
+			m[key] = p
+		}
+	}
+	return m
+}()
+
+// The presets in the order of preset.go (sorted by width).
+var presetInfos = []*PresetInfo{
+	newPresetInfo(CRC3GSM, "CRC-3/GSM"),
+	newPresetInfo(CRC3ROHC, "CRC-3/ROHC"),
+
+	newPresetInfo(CRC4INTERLAKEN, "CRC-4/INTERLAKEN"),
+	newPresetInfo(CRC4G704, "CRC-4/G-704", "CRC-4/ITU"),
+
+	newPresetInfo(CRC5USB, "CRC-5/USB"),
+	newPresetInfo(CRC5EPCC1G2, "CRC-5/EPC-C1G2", "CRC-5/EPC"),
+	newPresetInfo(CRC5G704, "CRC-5/G-704", "CRC-5/ITU"),
+
+	newPresetInfo(CRC6G704, "CRC-6/G-704", "CRC-6/ITU"),
+	newPresetInfo(CRC6CDMA2000B, "CRC-6/CDMA2000-B"),
+	newPresetInfo(CRC6DARC, "CRC-6/DARC"),
+	newPresetInfo(CRC6CDMA2000A, "CRC-6/CDMA2000-A"),
+	newPresetInfo(CRC6GSM, "CRC-6/GSM"),
+
+	newPresetInfo(CRC7MMC, "CRC-7/MMC", "CRC-7"),
+	newPresetInfo(CRC7UMTS, "CRC-7/UMTS"),
+	newPresetInfo(CRC7ROHC, "CRC-7/ROHC"),
+
+	newPresetInfo(CRC8SMBUS, "CRC-8/SMBUS", "CRC-8"),
+	newPresetInfo(CRC8I4321, "CRC-8/I-432-1"),
+	newPresetInfo(CRC8ROHC, "CRC-8/ROHC"),
+	newPresetInfo(CRC8GSMA, "CRC-8/GSM-A"),
+	newPresetInfo(CRC8MIFAREMAD, "CRC-8/MIFARE-MAD"),
+	newPresetInfo(CRC8ICODE, "CRC-8/I-CODE"),
+	newPresetInfo(CRC8HITAG, "CRC-8/HITAG"),
+	newPresetInfo(CRC8SAEJ1850, "CRC-8/SAE-J1850"),
+	newPresetInfo(CRC8TECH3250, "CRC-8/TECH-3250", "CRC-8/AES", "CRC-8/EBU"),
+	newPresetInfo(CRC8OPENSAFETY, "CRC-8/OPENSAFETY"),
+	newPresetInfo(CRC8AUTOSAR, "CRC-8/AUTOSAR"),
+	newPresetInfo(CRC8NRSC5, "CRC-8/NRSC-5"),
+	newPresetInfo(CRC8MAXIMDOW, "CRC-8/MAXIM-DOW", "CRC-8/MAXIM", "DOW-CRC"),
+	newPresetInfo(CRC8DARC, "CRC-8/DARC"),
+	newPresetInfo(CRC8GSMB, "CRC-8/GSM-B"),
+	newPresetInfo(CRC8LTE, "CRC-8/LTE"),
+	newPresetInfo(CRC8CDMA2000, "CRC-8/CDMA2000"),
+	newPresetInfo(CRC8WCDMA, "CRC-8/WCDMA"),
+	newPresetInfo(CRC8BLUETOOTH, "CRC-8/BLUETOOTH"),
+	newPresetInfo(CRC8DVBS2, "CRC-8/DVB-S2"),
+
+	newPresetInfo(CRC10GSM, "CRC-10/GSM"),
+	newPresetInfo(CRC10ATM, "CRC-10/ATM", "CRC-10", "CRC-10/I-610"),
+	newPresetInfo(CRC10CDMA2000, "CRC-10/CDMA2000"),
+
+	newPresetInfo(CRC11UMTS, "CRC-11/UMTS"),
+	newPresetInfo(CRC11FLEXRAY, "CRC-11/FLEXRAY"),
+
+	newPresetInfo(CRC12DECT, "CRC-12/DECT", "X-CRC-12"),
+	newPresetInfo(CRC12UMTS, "CRC-12/UMTS", "CRC-12/3GPP"),
+	newPresetInfo(CRC12GSM, "CRC-12/GSM"),
+	newPresetInfo(CRC12CDMA2000, "CRC-12/CDMA2000"),
+
+	newPresetInfo(CRC13BBC, "CRC-13/BBC"),
+
+	newPresetInfo(CRC14DARC, "CRC-14/DARC"),
+	newPresetInfo(CRC14GSM, "CRC-14/GSM"),
+
+	newPresetInfo(CRC15CAN, "CRC-15/CAN", "CRC-15"),
+	newPresetInfo(CRC15MPT1327, "CRC-15/MPT1327"),
+
+	newPresetInfo(CRC16DECTX, "CRC-16/DECT-X", "X-CRC-16"),
+	newPresetInfo(CRC16DECTR, "CRC-16/DECT-R", "R-CRC-16"),
+	newPresetInfo(CRC16NRSC5, "CRC-16/NRSC-5"),
+	newPresetInfo(CRC16XMODEM, "CRC-16/XMODEM", "CRC-16/ACORN", "CRC-16/LTE", "CRC-16/V-41-MSB", "XMODEM", "ZMODEM"),
+	newPresetInfo(CRC16GSM, "CRC-16/GSM"),
+	newPresetInfo(CRC16SPIFUJITSU, "CRC-16/SPI-FUJITSU", "CRC-16/AUG-CCITT"),
+	newPresetInfo(CRC16IBM3740, "CRC-16/IBM-3740", "CRC-16/AUTOSAR", "CRC-16/CCITT-FALSE"),
+	newPresetInfo(CRC16GENIBUS, "CRC-16/GENIBUS", "CRC-16/DARC", "CRC-16/EPC", "CRC-16/EPC-C1G2", "CRC-16/I-CODE"),
+	newPresetInfo(CRC16KERMIT, "CRC-16/KERMIT", "CRC-16/BLUETOOTH", "CRC-16/CCITT", "CRC-16/CCITT-TRUE", "CRC-16/V-41-LSB", "CRC-CCITT", "KERMIT"),
+	newPresetInfo(CRC16TMS37157, "CRC-16/TMS37157"),
+	newPresetInfo(CRC16RIELLO, "CRC-16/RIELLO"),
+	newPresetInfo(CRC16ISOIEC144433A, "CRC-16/ISO-IEC-14443-3-A", "CRC-A"),
+	newPresetInfo(CRC16MCRF4XX, "CRC-16/MCRF4XX"),
+	newPresetInfo(CRC16IBMSDLC, "CRC-16/IBM-SDLC", "CRC-16/ISO-HDLC", "CRC-16/ISO-IEC-14443-3-B", "CRC-16/X-25", "CRC-B", "X-25"),
+	newPresetInfo(CRC16PROFIBUS, "CRC-16/PROFIBUS", "CRC-16/IEC-61158-2"),
+	newPresetInfo(CRC16EN13757, "CRC-16/EN-13757"),
+	newPresetInfo(CRC16DNP, "CRC-16/DNP"),
+	newPresetInfo(CRC16OPENSAFETYA, "CRC-16/OPENSAFETY-A"),
+	newPresetInfo(CRC16M17, "CRC-16/M17"),
+	newPresetInfo(CRC16LJ1200, "CRC-16/LJ1200"),
+	newPresetInfo(CRC16OPENSAFETYB, "CRC-16/OPENSAFETY-B"),
+	newPresetInfo(CRC16UMTS, "CRC-16/UMTS", "CRC-16/BUYPASS", "CRC-16/VERIFONE"),
+	newPresetInfo(CRC16DDS110, "CRC-16/DDS-110"),
+	newPresetInfo(CRC16CMS, "CRC-16/CMS"),
+	newPresetInfo(CRC16ARC, "CRC-16/ARC", "ARC", "CRC-16", "CRC-16/LHA", "CRC-IBM"),
+	newPresetInfo(CRC16MAXIMDOW, "CRC-16/MAXIM-DOW", "CRC-16/MAXIM"),
+	newPresetInfo(CRC16MODBUS, "CRC-16/MODBUS", "MODBUS"),
+	newPresetInfo(CRC16USB, "CRC-16/USB"),
+	newPresetInfo(CRC16T10DIF, "CRC-16/T10-DIF"),
+	newPresetInfo(CRC16TELEDISK, "CRC-16/TELEDISK"),
+	newPresetInfo(CRC16CDMA2000, "CRC-16/CDMA2000"),
+
+	newPresetInfo(CRC17CANFD, "CRC-17/CAN-FD"),
+
+	newPresetInfo(CRC21CANFD, "CRC-21/CAN-FD"),
+
+	newPresetInfo(CRC24BLE, "CRC-24/BLE"),
+	newPresetInfo(CRC24INTERLAKEN, "CRC-24/INTERLAKEN"),
+	newPresetInfo(CRC24FLEXRAYB, "CRC-24/FLEXRAY-B"),
+	newPresetInfo(CRC24FLEXRAYA, "CRC-24/FLEXRAY-A"),
+	newPresetInfo(CRC24LTEB, "CRC-24/LTE-B"),
+	newPresetInfo(CRC24OS9, "CRC-24/OS-9"),
+	newPresetInfo(CRC24LTEA, "CRC-24/LTE-A"),
+	newPresetInfo(CRC24OPENPGP, "CRC-24/OPENPGP", "CRC-24"),
+
+	newPresetInfo(CRC30CDMA, "CRC-30/CDMA"),
+
+	newPresetInfo(CRC31PHILIPS, "CRC-31/PHILIPS"),
+
+	newPresetInfo(CRC32XFER, "CRC-32/XFER"),
+	newPresetInfo(CRC32CKSUM, "CRC-32/CKSUM", "CKSUM", "CRC-32/POSIX"),
+	newPresetInfo(CRC32MPEG2, "CRC-32/MPEG-2"),
+	newPresetInfo(CRC32BZIP2, "CRC-32/BZIP2", "CRC-32/AAL5", "CRC-32/DECT-B", "B-CRC-32"),
+	newPresetInfo(CRC32JAMCRC, "CRC-32/JAMCRC", "JAMCRC"),
+	newPresetInfo(CRC32ISOHDLC, "CRC-32/ISO-HDLC", "CRC-32", "CRC-32/ADCCP", "CRC-32/V-42", "CRC-32/XZ", "PKZIP"),
+	newPresetInfo(CRC32ISCSI, "CRC-32/ISCSI", "CRC-32/BASE91-C", "CRC-32/CASTAGNOLI", "CRC-32/INTERLAKEN", "CRC-32C"),
+	newPresetInfo(CRC32MEF, "CRC-32/MEF"),
+	newPresetInfo(CRC32CDROMEDC, "CRC-32/CD-ROM-EDC"),
+	newPresetInfo(CRC32AIXM, "CRC-32/AIXM", "CRC-32Q"),
+	newPresetInfo(CRC32BASE91D, "CRC-32/BASE91-D", "CRC-32D"),
+	newPresetInfo(CRC32AUTOSAR, "CRC-32/AUTOSAR"),
+
+	newPresetInfo(CRC40GSM, "CRC-40/GSM"),
+
+	newPresetInfo(CRC64GOISO, "CRC-64/GO-ISO"),
+	newPresetInfo(CRC64MS, "CRC-64/MS"),
+	newPresetInfo(CRC64ECMA182, "CRC-64/ECMA-182", "CRC-64"),
+	newPresetInfo(CRC64WE, "CRC-64/WE"),
+	newPresetInfo(CRC64XZ, "CRC-64/XZ", "CRC-64/GO-ECMA"),
+	newPresetInfo(CRC64REDIS, "CRC-64/REDIS"),
+}
+
+var presets128ByName = func() map[string]*PresetInfo128 {
+	m := make(map[string]*PresetInfo128)
+	for _, p := range presetInfos128 {
+		for _, name := range append([]string{p.Name}, p.Aliases...) {
+			key := normalizePresetName(name)
+			if _, ok := m[key]; ok {
+				panic("duplicate preset name: " + name)
+			}
+			if _, ok := presetsByName[key]; ok {
+				panic("duplicate preset name: " + name)
+			}
+			m[key] = p
+		}
+	}
+	return m
+}()
+
+var presetInfos128 = []*PresetInfo128{
+	{"CRC-82/DARC", nil, CRC82DARC},
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestLookupPreset(t *testing.T) {
+	tests := []struct {
+		name     string
+		official string
+	}{
+		{"CRC-32/ISO-HDLC", "CRC-32/ISO-HDLC"},
+		{"crc-32", "CRC-32/ISO-HDLC"},
+		{"PKZIP", "CRC-32/ISO-HDLC"},
+		{"crc_32 / xz", "CRC-32/ISO-HDLC"},
+		{"CRC16CCITTFALSE", "CRC-16/IBM-3740"},
+		{"x-25", "CRC-16/IBM-SDLC"},
+		{"CRC-8", "CRC-8/SMBUS"},
+		{"crc-8/darc", "CRC-8/DARC"},
+		{"CRC-64", "CRC-64/ECMA-182"},
+	}
+	for _, tc := range tests {
+		p, ok := crc.LookupPreset(tc.name)
+		if !ok {
+			t.Errorf("%q: preset not found", tc.name)
+		} else if p.Name != tc.official {
+			t.Errorf("%q: name=%q, want %q", tc.name, p.Name, tc.official)
+		}
+	}
+	if _, ok := crc.LookupPreset("CRC-32/NONEXISTENT"); ok {
+		t.Error("found a nonexistent preset")
+	}
+}
+
+func TestPresets(t *testing.T) {
+	all := crc.Presets()
+	if len(all) != len(presets) {
+		t.Errorf("number of presets=%d, want %d", len(all), len(presets))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Params.Width > all[i].Params.Width {
+			t.Errorf("%s and %s aren't sorted by width", all[i-1].Name, all[i].Name)
+		}
+	}
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			info, ok := crc.LookupPreset(p.name)
+			if !ok {
+				t.Fatal("preset not found")
+			}
+			if info.Params != p.preset.Params() {
+				t.Errorf("params=%+v, want %+v", info.Params, p.preset.Params())
+			}
+			if c := info.Algo.Calc([]byte("123456789")); c != p.check {
+				t.Errorf("check=%x, want %x", c, p.check)
+			}
+		})
+	}
+}
+
+func TestLookupPreset128(t *testing.T) {
+	p, ok := crc.LookupPreset128("crc82darc")
+	if !ok || p.Name != "CRC-82/DARC" || p.Algo != crc.CRC82DARC {
+		t.Fatalf("preset=%+v ok=%t", p, ok)
+	}
+	if _, ok := crc.LookupPreset128("CRC-32"); ok {
+		t.Error("found a 32-bit preset")
+	}
+	if _, ok := crc.LookupPreset("CRC-82/DARC"); ok {
+		t.Error("LookupPreset found a 128-bit preset")
+	}
+	if n := len(crc.Presets128()); n != 1 {
+		t.Errorf("len(Presets128)=%d, want 1", n)
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import "math"
+
+// Processing a pattern of m bits maps the register to reg*x^m + d (mod P)
+// where d is the register after processing the pattern from a zero register.
+// Applying this affine map twice gives reg*x^(2m) + d*x^m + d so the map of
+// 2^k repetitions is computed by repeated squaring like x^n in xPow.
+
+func (c *crc[T]) UpdateRepeated(pattern []byte, n int64) {
+	if n < 0 {
+		panic("n is negative")
+	}
+	c.reg = c.a.repeat(c.reg, pattern, n)
+	c.addBitLen(int64(len(pattern))<<3, n)
+}
+
+func (c *crc[T]) UpdateZeroBits(bitLen int64) {
+	if bitLen < 0 {
+		panic("bitLen is negative")
+	}
+	c.reg = c.a.shift(c.reg, bitLen)
+	c.addBitLen(bitLen, 1)
+}
+
+// addBitLen adds n times bitLen to the number of processed bits. The sum
+// saturates at math.MaxInt64 instead of overflowing.
+func (c *crc[T]) addBitLen(bitLen, n int64) {
+	if bitLen != 0 && n > (math.MaxInt64-c.bitLen)/bitLen {
+		c.bitLen = math.MaxInt64
+	} else {
+		c.bitLen += bitLen * n
+	}
+}
+
+// repeat returns the register after processing n repetitions of pattern.
+func (a *algo[T]) repeat(reg T, pattern []byte, n int64) T {
+	if n == 0 || len(pattern) == 0 {
+		return reg
+	}
+	m := a.xPow(int64(len(pattern)) << 3)
+	d := a.tblUpd(0, pattern, -1)
+	for ; n != 0; n >>= 1 {
+		if n&1 != 0 {
+			reg = a.mulMod(reg, m) ^ d
+		}
+		d = a.mulMod(d, m) ^ d
+		m = a.mulMod(m, m)
+	}
+	return reg
+}
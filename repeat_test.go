@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+)
+
+func TestUpdateRepeated(t *testing.T) {
+	patterns := [][]byte{{0x00}, {0xff}, {0xa5}, []byte("abc"), nil}
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			for _, pattern := range patterns {
+				for _, n := range []int64{0, 1, 2, 3, 7, 100, 1000} {
+					c := p.preset.NewCRC()
+					c.UpdateBits([]byte{0x5a}, 5)
+					want := c.Clone()
+					want.Update(bytes.Repeat(pattern, int(n)))
+					c.UpdateRepeated(pattern, n)
+					if c.Final() != want.Final() || c.BitLen() != want.BitLen() {
+						t.Errorf("pattern=%x n=%d: crc=%x bitLen=%d, want %x %d", pattern, n,
+							c.Final(), c.BitLen(), want.Final(), want.BitLen())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateZeroBits(t *testing.T) {
+	zeros := make([]byte, 200)
+	for _, p := range presets {
+		t.Run(p.name, func(t *testing.T) {
+			for _, n := range []int{0, 1, 5, 8, 13, 64, 1000, 1600} {
+				c := p.preset.NewCRC()
+				c.Update([]byte("123456789"))
+				want := c.Clone()
+				want.UpdateBits(zeros, n)
+				c.UpdateZeroBits(int64(n))
+				if c.Final() != want.Final() || c.BitLen() != want.BitLen() {
+					t.Errorf("n=%d: crc=%x bitLen=%d, want %x %d", n, c.Final(), c.BitLen(),
+						want.Final(), want.BitLen())
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateRepeatedHuge(t *testing.T) {
+	const n = math.MaxInt64 / 8
+	c := crc.CRC32.NewCRC()
+	c.UpdateRepeated([]byte{0}, n)
+	want := crc.CRC32.NewCRC()
+	want.UpdateZeroBits(8 * n)
+	if c.Final() != want.Final() || c.BitLen() != 8*n {
+		t.Errorf("crc=%x bitLen=%d, want %x %d", c.Final(), c.BitLen(), want.Final(), int64(8*n))
+	}
+	c.UpdateRepeated([]byte{0}, n)
+	if c.BitLen() != math.MaxInt64 {
+		t.Errorf("bitLen=%d after overflow, want %d", c.BitLen(), int64(math.MaxInt64))
+	}
+	c.UpdateZeroBits(8)
+	if c.BitLen() != math.MaxInt64 {
+		t.Errorf("bitLen=%d after UpdateZeroBits, want %d", c.BitLen(), int64(math.MaxInt64))
+	}
+}
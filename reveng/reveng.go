@@ -0,0 +1,401 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+// Package reveng recovers the parameters of unknown CRC algorithms from
+// sample messages and their CRCs like Greg Cook's CRC RevEng tool.
+//
+// The poly is found with the classic trick of XOR-ing samples of the same
+// length: the XOR of two such messages has the XOR of the two CRCs as its CRC
+// with init=0 and xorout=0 so the poly has to be a divisor of the polynomial
+// formed by the XOR-ed message followed by the XOR-ed CRC. Init and xorout
+// are then found by solving a system of linear equations over GF(2).
+package reveng
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/pasztorpisti/go-crc"
+	"github.com/pasztorpisti/go-crc/gf2"
+)
+
+// Sample is a message with its CRC.
+type Sample struct {
+	Data   []byte
+	BitLen int    // number of message bits in Data, zero means 8*len(Data)
+	CRC    uint64 // the CRC of the message
+}
+
+// Reflection is a combination of the refin and refout parameters.
+type Reflection struct {
+	RefIn, RefOut bool
+}
+
+// Options restrict the search space of Solve.
+type Options struct {
+	Width int    // bit width of the CRC, zero means unknown
+	Poly  uint64 // MSB-first poly (like the parameter of crc.NewAlgo), zero means unknown
+
+	// Reflections lists the refin/refout combinations to try.
+	// Nil means all four combinations.
+	Reflections []Reflection
+}
+
+// Model is a CRC algorithm that reproduces the CRCs of all samples.
+type Model struct {
+	crc.Params[uint64]
+
+	// Ambiguous is true if other init and xorout values would also reproduce
+	// the CRCs of the samples. This happens for example if all samples have
+	// the same length: in that case Init is zero and XorOut absorbs the effect
+	// of the real init so the model works only with messages of that length.
+	Ambiguous bool
+}
+
+// maxCofactorDeg limits the search for divisors of the polynomial that has to
+// be divisible by the poly: the excess degree of that polynomial is reduced
+// by adding more samples of the same length.
+const maxCofactorDeg = 16
+
+// maxNullSpaceDim limits the number of equivalent init/xorout pairs returned
+// for a poly. Polys divisible by x+1 (e.g. 0x1021) always have two of them:
+// adding P/(x+1) to init adds the same constant to the CRC of any message.
+const maxNullSpaceDim = 4
+
+// ErrTooFewSamples is returned by Solve if the samples don't contain enough
+// information to find the poly.
+var ErrTooFewSamples = errors.New("too few samples of the same length to find the poly")
+
+// Solve returns the CRC algorithms that reproduce the CRCs of all samples.
+// Without a known poly at least two samples of the same length are required
+// but usually a few more are needed to narrow down the list of candidates.
+// At least one sample of a different length is required to tell init and
+// xorout apart.
+func Solve(samples []Sample, opts *Options) ([]Model, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if len(samples) == 0 {
+		return nil, errors.New("no samples")
+	}
+	for i, s := range samples {
+		if s.BitLen < 0 || s.BitLen > len(s.Data)<<3 {
+			return nil, fmt.Errorf("sample %d: BitLen must be between 0 and 8*len(Data)", i)
+		}
+	}
+	widths := []int{opts.Width}
+	if opts.Width == 0 {
+		widths = nil
+		for w := 64; w >= 1; w-- {
+			widths = append(widths, w)
+		}
+	} else if opts.Width < 0 || opts.Width > 64 {
+		return nil, errors.New("width must be between 1 and 64")
+	}
+	refls := opts.Reflections
+	if refls == nil {
+		refls = []Reflection{{false, false}, {true, true}, {false, true}, {true, false}}
+	}
+
+	var models []Model
+	var lastErr error
+	for _, w := range widths {
+		if !fits(samples, w) {
+			continue
+		}
+		for _, refl := range refls {
+			polys := []uint64{opts.Poly}
+			if opts.Poly == 0 {
+				var err error
+				if polys, err = findPolys(samples, w, refl); err != nil {
+					lastErr = err
+					continue
+				}
+			}
+			for _, p := range polys {
+				models = append(models, solveInitXorout(samples, w, p, refl)...)
+			}
+		}
+	}
+	if len(models) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	sortModels(models)
+	return models, nil
+}
+
+func fits(samples []Sample, width int) bool {
+	for _, s := range samples {
+		if width < 64 && s.CRC>>width != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// findPolys returns the MSB-first polys (without the x^width term) that can
+// produce the XOR of the CRCs of each pair of samples of the same length.
+func findPolys(samples []Sample, width int, refl Reflection) ([]uint64, error) {
+	byLen := make(map[int][]Sample)
+	for _, s := range samples {
+		n := bitLen(s)
+		byLen[n] = append(byLen[n], s)
+	}
+	var g gf2.Poly
+	for _, group := range byLen {
+		for _, s := range group[1:] {
+			d := msgPoly(group[0], refl.RefIn).Add(msgPoly(s, refl.RefIn)).Shift(width)
+			d = d.Add(gf2.FromUint64(lowBits(normalCRC(group[0].CRC^s.CRC, width, refl.RefOut), width)))
+			g = gf2.GCD(g, d)
+		}
+	}
+	if g.Deg() < width {
+		if g.IsZero() {
+			return nil, ErrTooFewSamples
+		}
+		return nil, nil
+	}
+	cofactorDeg := g.Deg() - width
+	if cofactorDeg > maxCofactorDeg {
+		return nil, ErrTooFewSamples
+	}
+	var polys []uint64
+	for q := uint64(1) << cofactorDeg; q < uint64(2)<<cofactorDeg; q++ {
+		// polys without the +1 term are skipped: they are equivalent to
+		// narrower CRCs with their output shifted left
+		p, r := g.DivMod(gf2.FromUint64(q))
+		if r.IsZero() && p.Coeff(0) {
+			polys = append(polys, lowBits(p.Uint64(), width))
+		}
+	}
+	return polys, nil
+}
+
+// solveInitXorout finds init and xorout for the given poly.
+//
+// In the MSB-first (unreflected) domain the CRC of an n-bit message M is:
+//
+//	crc = (init*x^n + M*x^width mod P) + xorout
+//
+// so crc + crc0(M) = init*x^n mod P + xorout where crc0 is the CRC with
+// init=0 and xorout=0. This is a system of linear equations over GF(2) with
+// the bits of init and xorout as unknowns.
+//
+// If the solution isn't unique then all solutions are returned as ambiguous
+// models if there are at most 2^maxNullSpaceDim of them and only the one
+// with the lowest init otherwise.
+func solveInitXorout(samples []Sample, width int, p uint64, refl Reflection) []Model {
+	a0, err := crc.NewAlgo[uint64](width, p, 0, 0, refl.RefIn, false)
+	if err != nil {
+		return nil
+	}
+	var sys linearSystem
+	for _, s := range samples {
+		rhs := normalCRC(s.CRC, width, refl.RefOut) ^ a0.CalcBits(s.Data, bitLen(s))
+		// column j of the matrix that multiplies init by x^n is x^j*x^n mod P
+		var cols [64]uint64
+		xn := xPowMod(int64(bitLen(s)), p, width)
+		for j := 0; j < width; j++ {
+			cols[j] = xn
+			xn = mulX(xn, p, width)
+		}
+		for i := 0; i < width; i++ {
+			var e equation
+			e.coef[0] = 1 << i
+			for j := 0; j < width; j++ {
+				e.coef[1] |= (cols[j] >> i & 1) << j
+			}
+			e.rhs = rhs>>i&1 != 0
+			sys = append(sys, e)
+		}
+	}
+	solution, nullSpace, ok := sys.solve(width)
+	if !ok {
+		return nil
+	}
+	ambiguous := len(nullSpace) != 0
+	if len(nullSpace) > maxNullSpaceDim {
+		nullSpace = nil
+	}
+	var models []Model
+	for i := 0; i < 1<<len(nullSpace); i++ {
+		v := solution
+		for j, nv := range nullSpace {
+			if i>>j&1 != 0 {
+				v[0] ^= nv[0]
+				v[1] ^= nv[1]
+			}
+		}
+		init, xorout := v[1], v[0]
+		if refl.RefOut {
+			xorout = reflect(xorout, width)
+		}
+		models = append(models, Model{crc.Params[uint64]{Width: width, Poly: p, Init: init,
+			XorOut: xorout, RefIn: refl.RefIn, RefOut: refl.RefOut}, ambiguous})
+	}
+	return models
+}
+
+// equation is a linear equation over GF(2): the XOR of the unknowns selected
+// by coef is rhs. Unknown i<64 is bit i of xorout and unknown i>=64 is bit
+// i-64 of init.
+type equation struct {
+	coef [2]uint64
+	rhs  bool
+}
+
+func (e *equation) has(u int) bool {
+	return e.coef[u>>6]>>(u&63)&1 != 0
+}
+
+type linearSystem []equation
+
+// solve performs Gauss-Jordan elimination and returns a particular solution
+// (with all free unknowns set to zero) and a basis of the null space. Since
+// the xorout bits are eliminated first the free unknowns are init bits.
+func (sys linearSystem) solve(width int) (solution [2]uint64, nullSpace [][2]uint64, ok bool) {
+	var pivots []int // pivots[row] is the unknown eliminated by row
+	var isPivot [2]uint64
+	for u := 0; u < 128; u++ {
+		r := len(pivots)
+		k := r
+		for k < len(sys) && !sys[k].has(u) {
+			k++
+		}
+		if k == len(sys) {
+			continue
+		}
+		sys[r], sys[k] = sys[k], sys[r]
+		for i := range sys {
+			if i != r && sys[i].has(u) {
+				sys[i].coef[0] ^= sys[r].coef[0]
+				sys[i].coef[1] ^= sys[r].coef[1]
+				sys[i].rhs = sys[i].rhs != sys[r].rhs
+			}
+		}
+		pivots = append(pivots, u)
+		isPivot[u>>6] |= 1 << (u & 63)
+	}
+	for _, e := range sys[len(pivots):] {
+		if e.rhs {
+			return solution, nil, false
+		}
+	}
+	for r, u := range pivots {
+		if sys[r].rhs {
+			solution[u>>6] |= 1 << (u & 63)
+		}
+	}
+	for _, f := range []int{0, 64} {
+		for u := f; u < f+width; u++ {
+			if isPivot[u>>6]>>(u&63)&1 != 0 {
+				continue
+			}
+			var v [2]uint64
+			v[u>>6] |= 1 << (u & 63)
+			for r, pu := range pivots {
+				if sys[r].has(u) {
+					v[pu>>6] |= 1 << (pu & 63)
+				}
+			}
+			nullSpace = append(nullSpace, v)
+		}
+	}
+	return solution, nullSpace, true
+}
+
+func bitLen(s Sample) int {
+	if s.BitLen == 0 {
+		return len(s.Data) << 3
+	}
+	return s.BitLen
+}
+
+// msgPoly returns the message as a polynomial: the first processed bit is
+// the coefficient of the highest power.
+func msgPoly(s Sample, refin bool) gf2.Poly {
+	n := bitLen(s)
+	p := make([]uint64, (n+63)>>6)
+	for i := 0; i < n; i++ {
+		b := s.Data[i>>3]
+		var bit byte
+		if refin {
+			bit = b >> (i & 7) & 1
+		} else {
+			bit = b >> (7 - i&7) & 1
+		}
+		if j := n - 1 - i; bit != 0 {
+			p[j>>6] |= 1 << (j & 63)
+		}
+	}
+	return gf2.New(p...)
+}
+
+// normalCRC converts a CRC value to MSB-first format.
+func normalCRC(v uint64, width int, refout bool) uint64 {
+	if refout {
+		return reflect(v, width)
+	}
+	return v
+}
+
+func reflect(v uint64, width int) uint64 {
+	return bits.Reverse64(v) >> (64 - width)
+}
+
+func lowBits(v uint64, n int) uint64 {
+	if n >= 64 {
+		return v
+	}
+	return v & (1<<n - 1)
+}
+
+// mulX returns v*x mod P in MSB-first format where p is P without the
+// x^width term.
+func mulX(v, p uint64, width int) uint64 {
+	top := v >> (width - 1) & 1
+	v <<= 1
+	if width < 64 {
+		v &= 1<<width - 1
+	}
+	if top != 0 {
+		v ^= p
+	}
+	return v
+}
+
+// mulMod returns a*b mod P in MSB-first format.
+func mulMod(a, b, p uint64, width int) uint64 {
+	var r uint64
+	for i := width - 1; i >= 0; i-- {
+		r = mulX(r, p, width)
+		if a>>i&1 != 0 {
+			r ^= b
+		}
+	}
+	return r
+}
+
+// xPowMod returns x^n mod P in MSB-first format.
+func xPowMod(n int64, p uint64, width int) uint64 {
+	r, sq := uint64(1), mulX(1, p, width)
+	for ; n != 0; n >>= 1 {
+		if n&1 != 0 {
+			r = mulMod(r, sq, p, width)
+		}
+		sq = mulMod(sq, sq, p, width)
+	}
+	return r
+}
+
+// sortModels sorts the models by width and then by poly.
+func sortModels(models []Model) {
+	sort.SliceStable(models, func(i, j int) bool {
+		if models[i].Width != models[j].Width {
+			return models[i].Width < models[j].Width
+		}
+		return models[i].Poly < models[j].Poly
+	})
+}
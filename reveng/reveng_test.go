@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package reveng_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pasztorpisti/go-crc"
+	"github.com/pasztorpisti/go-crc/reveng"
+)
+
+func samplesOf(a crc.AnyAlgo, rnd *rand.Rand, bitLens ...int) []reveng.Sample {
+	var samples []reveng.Sample
+	for _, n := range bitLens {
+		data := make([]byte, (n+7)/8)
+		rnd.Read(data)
+		samples = append(samples, reveng.Sample{Data: data, BitLen: n, CRC: a.CalcBits(data, n)})
+	}
+	return samples
+}
+
+func contains(models []reveng.Model, params crc.Params[uint64]) bool {
+	for _, m := range models {
+		if m.Params == params {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSolve(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	for _, p := range crc.Presets() {
+		t.Run(p.Name, func(t *testing.T) {
+			samples := samplesOf(p.Algo, rnd, 128, 128, 128, 128, 128, 160)
+			models, err := reveng.Solve(samples, &reveng.Options{Width: p.Params.Width})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !contains(models, p.Params) {
+				t.Errorf("models=%+v, want %+v", models, p.Params)
+			}
+		})
+	}
+}
+
+func TestSolveUnknownWidth(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	for _, name := range []string{"CRC-5/USB", "CRC-12/UMTS", "CRC-16/XMODEM", "CRC-32/ISO-HDLC", "CRC-64/XZ"} {
+		p, _ := crc.LookupPreset(name)
+		t.Run(name, func(t *testing.T) {
+			samples := samplesOf(p.Algo, rnd, 77, 77, 77, 77, 77, 77, 93)
+			models, err := reveng.Solve(samples, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !contains(models, p.Params) {
+				t.Errorf("models=%+v, want %+v", models, p.Params)
+			}
+		})
+	}
+}
+
+func TestSolveKnownPoly(t *testing.T) {
+	samples := []reveng.Sample{{Data: []byte("123456789"), CRC: 0x29b1}, {Data: []byte("12345678"), CRC: 0xa12b}}
+	models, err := reveng.Solve(samples, &reveng.Options{Width: 16, Poly: 0x1021,
+		Reflections: []reveng.Reflection{{false, false}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0x1021 is divisible by x+1 so there is an equivalent init/xorout pair
+	want := crc.Params[uint64]{Width: 16, Poly: 0x1021, Init: 0xffff, XorOut: 0}
+	if len(models) != 2 || !contains(models, want) || !models[0].Ambiguous {
+		t.Errorf("models=%+v, want %+v", models, want)
+	}
+}
+
+func TestSolveSameLength(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	samples := samplesOf(crc.CRC16IBM3740.Any(), rnd, 64, 64, 64, 64)
+	models, err := reveng.Solve(samples, &reveng.Options{Width: 16, Reflections: []reveng.Reflection{{false, false}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) == 0 || models[0].Poly != 0x1021 || !models[0].Ambiguous || models[0].Init != 0 {
+		t.Errorf("models=%+v", models)
+	}
+}
+
+func TestSolveTooFewSamples(t *testing.T) {
+	samples := []reveng.Sample{{Data: []byte("123456789"), CRC: 0x29b1}}
+	if _, err := reveng.Solve(samples, &reveng.Options{Width: 16}); err != reveng.ErrTooFewSamples {
+		t.Errorf("err=%v, want %v", err, reveng.ErrTooFewSamples)
+	}
+}
+
+func TestSolveInvalidBitLen(t *testing.T) {
+	for _, bitLen := range []int{-1, 73} {
+		samples := []reveng.Sample{{Data: []byte("123456789"), BitLen: bitLen, CRC: 0x29b1}}
+		if _, err := reveng.Solve(samples, nil); err == nil {
+			t.Errorf("BitLen=%d: no error", bitLen)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// slicing8Threshold is the minimum input length in bytes for which the
+// slicing-by-8 method is used. The slicing-by-8 tables of an algorithm are
+// created when it receives its first input of at least this length.
+const slicing8Threshold = 256
+
+// slicing8Upd processes data with the slicing-by-8 method. The length of data
+// has to be a multiple of 8.
+//
+// The register is at most 64 bits wide so XOR-ing it into the next 8 bytes of
+// input leaves a zero register and its CRC becomes the XOR of the CRCs of the
+// 8 individual input bytes followed by 7...0 zero bytes (tables 7...0).
+func (a *algo[T]) slicing8Upd(reg T, data []byte) T {
+	t := a.slicing8Tables()
+	for ; len(data) >= 8; data = data[8:] {
+		x := binary.LittleEndian.Uint64(data)
+		if !a.refin {
+			x = bits.ReverseBytes64(bits.Reverse64(x)) // reflecting each byte
+		}
+		x ^= uint64(reg)
+		reg = t[7][byte(x)] ^ t[6][byte(x>>8)] ^ t[5][byte(x>>16)] ^ t[4][byte(x>>24)] ^
+			t[3][byte(x>>32)] ^ t[2][byte(x>>40)] ^ t[1][byte(x>>48)] ^ t[0][x>>56]
+	}
+	return reg
+}
+
+func (a *algo[T]) slicing8Tables() *[8][256]T {
+	a.slicing8Once.Do(func() {
+		t := new([8][256]T)
+		t[0] = a.table
+		for k := 1; k < 8; k++ {
+			for i := range t[k] {
+				v := t[k-1][i]
+				t[k][i] = shr8(v) ^ a.table[byte(v)]
+			}
+		}
+		a.slicing8 = t
+	})
+	return a.slicing8
+}
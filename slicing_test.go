@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// newImpl creates the implementation of an algorithm with the integer type T.
+func newImpl[T UInt](t *testing.T, params Params[uint64]) *algo[T] {
+	a, err := NewAlgo(params.Width, T(params.Poly), T(params.Init), T(params.XorOut), params.RefIn, params.RefOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a.(*algo[T])
+}
+
+// forEachImpl calls f with every preset in every integer type that can hold
+// its width.
+func forEachImpl(t *testing.T, f func(t *testing.T, params Params[uint64], bits int)) {
+	for _, p := range presetInfos {
+		for _, bits := range []int{8, 16, 32, 64} {
+			if p.Params.Width <= bits {
+				params := p.Params
+				t.Run(p.Name+"/uint"+strconv.Itoa(bits), func(t *testing.T) {
+					f(t, params, bits)
+				})
+			}
+		}
+	}
+}
+
+func TestSlicing8(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	data := make([]byte, 512)
+	rnd.Read(data)
+	forEachImpl(t, func(t *testing.T, params Params[uint64], bits int) {
+		switch bits {
+		case 8:
+			checkSlicing8(t, newImpl[uint8](t, params), rnd, data)
+		case 16:
+			checkSlicing8(t, newImpl[uint16](t, params), rnd, data)
+		case 32:
+			checkSlicing8(t, newImpl[uint32](t, params), rnd, data)
+		case 64:
+			checkSlicing8(t, newImpl[uint64](t, params), rnd, data)
+		}
+	})
+}
+
+func checkSlicing8[T UInt](t *testing.T, a *algo[T], rnd *rand.Rand, data []byte) {
+	for n := 0; n <= len(data); n += 8 {
+		reg := T(rnd.Uint64()) & (T(1)<<(a.width-1)<<1 - 1)
+		want := reg
+		for _, b := range data[:n] {
+			want = a.bbbUpd(want, b, 8)
+		}
+		if got := a.slicing8Upd(reg, data[:n]); got != want {
+			t.Fatalf("n=%d: reg=%x, want %x", n, got, want)
+		}
+	}
+}
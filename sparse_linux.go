@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+//go:build linux
+
+package crc
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+)
+
+// The whence values of lseek that find the data and the holes of sparse
+// files. The syscall package doesn't define them.
+const (
+	seekData = 3
+	seekHole = 4
+)
+
+// nextRange returns the length of the hole at the current offset of f and
+// the length of the data after the hole and moves the offset to the start of
+// the data. The data length is zero at the end of the file and -1 if the
+// file doesn't support finding holes: in that case the data ends at EOF.
+func nextRange(f *os.File) (hole, data int64, err error) {
+	off, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		// pipes, terminals, etc.
+		return 0, -1, nil
+	}
+	start, err := f.Seek(off, seekData)
+	if err != nil {
+		if errors.Is(err, syscall.ENXIO) {
+			// there is no data after off: the rest of the file is a hole
+			end, err := f.Seek(0, io.SeekEnd)
+			if err != nil || end < off {
+				// end < off if the offset was beyond the end of the file
+				return 0, 0, err
+			}
+			return end - off, 0, nil
+		}
+		// the offset is unchanged if lseek fails
+		return 0, -1, nil
+	}
+	end, err := f.Seek(start, seekHole)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := f.Seek(start, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+	return start - off, end - start, nil
+}
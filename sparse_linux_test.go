@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: MIT-0
+// SPDX-FileCopyrightText:  2024 Istvan Pasztor
+
+package crc
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNextRange(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "sparse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	const size, dataOff = 8 << 20, 4 << 20
+	if err := f.Truncate(size); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("data"), dataOff); err != nil {
+		t.Fatal(err)
+	}
+	hole, data, err := nextRange(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hole == 0 {
+		t.Skip("the file system doesn't support sparse files")
+	}
+	// the file system can allocate blocks around the written data
+	if hole > dataOff || hole+data < dataOff+4 || hole+data == size {
+		t.Fatalf("hole=%d data=%d", hole, data)
+	}
+	if _, err := f.Seek(hole+data, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if hole2, data2, err := nextRange(f); hole2 != size-hole-data || data2 != 0 || err != nil {
+		t.Errorf("trailing hole=%d data=%d err=%v", hole2, data2, err)
+	}
+}